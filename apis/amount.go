@@ -0,0 +1,278 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+)
+
+const (
+	// maxAmountScale is the maximum number of decimal places of an Amount.
+	maxAmountScale = 18
+	// maxAmountDigits is the maximum number of significant digits ParseAmount accepts.
+	maxAmountDigits = 38
+)
+
+// currencyMinorUnits contains the ISO 4217 currencies which do not have 2 minor units.
+var currencyMinorUnits = map[string]int32{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0, "JOD": 3,
+	"JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "RWF": 0,
+	"TND": 3, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// CurrencyMinorUnits returns the number of decimal places of the ISO 4217 currency code.
+// Unknown currency codes default to 2.
+func CurrencyMinorUnits(currencyCode string) int32 {
+	if units, ok := currencyMinorUnits[strings.ToUpper(currencyCode)]; ok {
+		return units
+	}
+	return 2
+}
+
+// CheckSameCurrency returns ErrCurrencyMismatch if the currency codes differ.
+func CheckSameCurrency(currencyCode string, other string) error {
+	if !strings.EqualFold(currencyCode, other) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, currencyCode, other)
+	}
+	return nil
+}
+
+// Amount is an exact decimal number for monetary values. It keeps the number of decimal
+// places it was parsed with, so "4.90" is marshaled as 4.90 again, and amounts which were
+// unmarshaled from JSON strings are marshaled as strings again. Use Cmp to compare amounts,
+// as == does not compare their values.
+// The zero value is 0. Amounts are not limited in size, so arithmetic never overflows.
+// The amount columns of flat file report documents are parsed by reports.FlatFileRow.Amount.
+type Amount struct {
+	// units is nil for 0 and never modified, as copies of an Amount share it.
+	units  *big.Int
+	scale  int32
+	quoted bool
+}
+
+// NewAmount returns the Amount units * 10^-scale, e.g. NewAmount(490, 2) is 4.90.
+func NewAmount(units int64, scale int32) Amount {
+	if scale < 0 || scale > maxAmountScale {
+		panic(fmt.Sprintf("apis: amount scale %d out of range", scale))
+	}
+	return Amount{units: big.NewInt(units), scale: scale}
+}
+
+// ParseAmount parses a decimal number like "-1234.56", "4.90" or "1e-2".
+func ParseAmount(s string) (Amount, error) {
+	str := strings.TrimSpace(s)
+	mantissa, exponent := str, int64(0)
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+		mantissa, exponent = str[:i], exp
+	}
+
+	negative := false
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		negative = mantissa[0] == '-'
+		mantissa = mantissa[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	scale := int64(len(fracPart)) - exponent
+	if scale > maxAmountScale {
+		return Amount{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, s, maxAmountScale)
+	}
+	if scale < 0 {
+		if -scale > maxAmountDigits {
+			return Amount{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+		}
+		digits += strings.Repeat("0", int(-scale))
+		scale = 0
+	}
+	if len(strings.TrimLeft(digits, "0")) > maxAmountDigits {
+		return Amount{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+
+	units, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if negative {
+		units.Neg(units)
+	}
+	return Amount{units: units, scale: int32(scale)}, nil
+}
+
+// MustParseAmount is like ParseAmount but panics if s is not a valid decimal number.
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// String returns the amount with all its decimal places, e.g. "-4.90".
+func (a Amount) String() string {
+	abs := new(big.Int).Abs(a.int()).String()
+	if a.scale > 0 {
+		if pad := int(a.scale) + 1 - len(abs); pad > 0 {
+			abs = strings.Repeat("0", pad) + abs
+		}
+		abs = abs[:len(abs)-int(a.scale)] + "." + abs[len(abs)-int(a.scale):]
+	}
+	if a.Sign() < 0 {
+		return "-" + abs
+	}
+	return abs
+}
+
+// Scale returns the number of decimal places.
+func (a Amount) Scale() int32 {
+	return a.scale
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of the amount.
+func (a Amount) Sign() int {
+	return a.int().Sign()
+}
+
+// IsZero reports whether the amount is 0, regardless of its decimal places.
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// Quoted returns the amount marshaled as JSON string, as the Orders APIs expect it.
+func (a Amount) Quoted() Amount {
+	a.quoted = true
+	return a
+}
+
+// Add returns a + b with the decimal places of the more precise amount.
+func (a Amount) Add(b Amount) Amount {
+	a, b = align(a, b)
+	return a.with(new(big.Int).Add(a.int(), b.int()))
+}
+
+// Sub returns a - b with the decimal places of the more precise amount.
+func (a Amount) Sub(b Amount) Amount {
+	return a.Add(b.Neg())
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return a.with(new(big.Int).Neg(a.int()))
+}
+
+// Abs returns the absolute value of a.
+func (a Amount) Abs() Amount {
+	if a.Sign() < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// MulInt returns a * n, e.g. the total of a unit price and a quantity.
+func (a Amount) MulInt(n int64) Amount {
+	return a.with(new(big.Int).Mul(a.int(), big.NewInt(n)))
+}
+
+// Cmp returns -1 if a < b, 0 if a == b and 1 if a > b. 4.9 and 4.90 are equal.
+func (a Amount) Cmp(b Amount) int {
+	a, b = align(a, b)
+	return a.int().Cmp(b.int())
+}
+
+// Round rounds the amount half away from zero to the given number of decimal places.
+// The result always has exactly that number of decimal places.
+func (a Amount) Round(places int32) Amount {
+	if places < 0 || places > maxAmountScale {
+		panic(fmt.Sprintf("apis: amount scale %d out of range", places))
+	}
+	if places >= a.scale {
+		return a.rescale(places)
+	}
+
+	divisor := pow10(a.scale - places)
+	quotient, remainder := new(big.Int).QuoRem(a.int(), divisor, new(big.Int))
+	remainder.Abs(remainder)
+	if remainder.Cmp(new(big.Int).Sub(divisor, remainder)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(a.Sign())))
+	}
+	return Amount{units: quotient, scale: places, quoted: a.quoted}
+}
+
+// RoundToCurrency rounds the amount to the minor units of the ISO 4217 currency code,
+// e.g. 2 decimal places for EUR and none for JPY.
+func (a Amount) RoundToCurrency(currencyCode string) Amount {
+	return a.Round(CurrencyMinorUnits(currencyCode))
+}
+
+// MarshalJSON marshals the amount with all its decimal places, as JSON string if it was
+// unmarshaled from one or created by Quoted, otherwise as JSON number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if a.quoted {
+		return []byte(`"` + a.String() + `"`), nil
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts JSON numbers and strings containing a decimal number, as the
+// Selling Partner APIs use both. null and the empty string leave the amount unchanged.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" || s == `""` {
+		return nil
+	}
+	quoted := false
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s, quoted = unquoted, true
+	}
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	parsed.quoted = quoted
+	*a = parsed
+	return nil
+}
+
+func (a Amount) int() *big.Int {
+	if a.units == nil {
+		return new(big.Int)
+	}
+	return a.units
+}
+
+// with returns an amount with the units and the scale and JSON form of a.
+func (a Amount) with(units *big.Int) Amount {
+	return Amount{units: units, scale: a.scale, quoted: a.quoted}
+}
+
+func (a Amount) rescale(scale int32) Amount {
+	if scale == a.scale {
+		return a
+	}
+	return Amount{units: new(big.Int).Mul(a.int(), pow10(scale-a.scale)), scale: scale, quoted: a.quoted}
+}
+
+func align(a, b Amount) (Amount, Amount) {
+	if a.scale < b.scale {
+		return a.rescale(b.scale), b
+	}
+	return a, b.rescale(a.scale)
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
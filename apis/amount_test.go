@@ -0,0 +1,146 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Integer", input: "42", want: "42"},
+		{name: "Trailing zero is kept", input: "4.90", want: "4.90"},
+		{name: "Negative", input: "-0.05", want: "-0.05"},
+		{name: "Plus sign", input: "+1.5", want: "1.5"},
+		{name: "Leading point", input: ".5", want: "0.5"},
+		{name: "Negative exponent", input: "1e-2", want: "0.01"},
+		{name: "Positive exponent", input: "1.5E3", want: "1500"},
+		{name: "Empty", input: "", wantErr: true},
+		{name: "Decimal comma", input: "4,90", wantErr: true},
+		{name: "Too many decimal places", input: "0.1234567890123456789", wantErr: true},
+		{name: "Beyond int64", input: "99999999999999999999", want: "99999999999999999999"},
+		{name: "Out of range", input: "1234567890123456789012345678901234567890", wantErr: true},
+		{name: "Exponent out of range", input: "1e1000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Errorf("ParseAmount() error = %v, want ErrInvalidAmount", err)
+				}
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseAmount() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Amount
+		want string
+	}{
+		{name: "Add aligns decimal places", got: MustParseAmount("19.99").Add(MustParseAmount("4.9")), want: "24.89"},
+		{name: "Add is exact", got: MustParseAmount("0.1").Add(MustParseAmount("0.2")), want: "0.3"},
+		{name: "Sub", got: MustParseAmount("4.90").Sub(MustParseAmount("19.99")), want: "-15.09"},
+		{name: "Neg", got: MustParseAmount("-3.10").Neg(), want: "3.10"},
+		{name: "Abs", got: MustParseAmount("-3.10").Abs(), want: "3.10"},
+		{name: "MulInt", got: MustParseAmount("19.99").MulInt(3), want: "59.97"},
+		{name: "Round half up", got: MustParseAmount("2.345").Round(2), want: "2.35"},
+		{name: "Round half away from zero", got: MustParseAmount("-2.345").Round(2), want: "-2.35"},
+		{name: "Round down", got: MustParseAmount("2.3449").Round(2), want: "2.34"},
+		{name: "Round pads decimal places", got: MustParseAmount("2.3").Round(2), want: "2.30"},
+		{name: "Round to EUR", got: MustParseAmount("10.005").RoundToCurrency("EUR"), want: "10.01"},
+		{name: "Round to JPY", got: MustParseAmount("1234.5").RoundToCurrency("JPY"), want: "1235"},
+		{name: "Round to KWD", got: MustParseAmount("1.23456").RoundToCurrency("KWD"), want: "1.235"},
+		{name: "Zero value", got: Amount{}.Add(NewAmount(5, 1)), want: "0.5"},
+		{name: "Add beyond int64 when aligning", got: MustParseAmount("1000").Add(MustParseAmount("0.0000000000000001")), want: "1000.0000000000000001"},
+		{name: "Sub beyond int64 when aligning", got: MustParseAmount("-1000").Sub(MustParseAmount("0.0000000000000001")), want: "-1000.0000000000000001"},
+		{name: "Neg of min int64", got: NewAmount(math.MinInt64, 0).Neg(), want: "9223372036854775808"},
+		{name: "MulInt beyond int64", got: NewAmount(math.MaxInt64, 2).MulInt(10), want: "922337203685477580.70"},
+		{name: "Round beyond int64", got: MustParseAmount("9999999999999999.995").Round(2), want: "10000000000000000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.String() != tt.want {
+				t.Errorf("got = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Cmp(t *testing.T) {
+	if got := MustParseAmount("4.9").Cmp(MustParseAmount("4.90")); got != 0 {
+		t.Errorf("Cmp() = %d, want 0", got)
+	}
+	if got := MustParseAmount("-1").Cmp(MustParseAmount("0.01")); got != -1 {
+		t.Errorf("Cmp() = %d, want -1", got)
+	}
+	if got := MustParseAmount("10").Cmp(MustParseAmount("9.999")); got != 1 {
+		t.Errorf("Cmp() = %d, want 1", got)
+	}
+	if got := MustParseAmount("1000").Cmp(MustParseAmount("1000.0000000000000001")); got != -1 {
+		t.Errorf("Cmp() = %d, want -1", got)
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type money struct {
+		Amount   Amount  `json:"amount"`
+		Optional *Amount `json:"optional,omitempty"`
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "Number", input: `{"amount":4.90}`, want: `{"amount":4.90}`},
+		{name: "String", input: `{"amount":"4.90"}`, want: `{"amount":"4.90"}`},
+		{name: "Negative string", input: `{"amount":"-19.99"}`, want: `{"amount":"-19.99"}`},
+		{name: "Large number without float rounding", input: `{"amount":12345678901234.56}`, want: `{"amount":12345678901234.56}`},
+		{name: "Null", input: `{"amount":null,"optional":null}`, want: `{"amount":0}`},
+		{name: "Empty string", input: `{"amount":""}`, want: `{"amount":0}`},
+		{name: "Optional", input: `{"amount":1,"optional":-0.01}`, want: `{"amount":1,"optional":-0.01}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m money
+			if err := json.Unmarshal([]byte(tt.input), &m); err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	quoted, err := json.Marshal(MustParseAmount("4.90").Quoted().Add(MustParseAmount("1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(quoted) != `"5.90"` {
+		t.Errorf("got = %s, want %q", quoted, "5.90")
+	}
+
+	var m money
+	if err := json.Unmarshal([]byte(`{"amount":"abc"}`), &m); err == nil {
+		t.Error("expected error for invalid amount")
+	}
+}
@@ -0,0 +1,35 @@
+package applications
+
+import (
+	"go/types"
+	"net/http"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+)
+
+const pathPrefix = "/applications/2023-11-30"
+
+type API struct {
+	httpClient *httpx.Client
+}
+
+func NewAPI(httpClient *httpx.Client) *API {
+	return &API{
+		httpClient: httpClient,
+	}
+}
+
+// RotateApplicationClientSecret rotates the LWA client secret of the application. The new secret is delivered
+// asynchronously with an APPLICATION_OAUTH_CLIENT_NEW_SECRET notification, see ClientSecretRotator.
+// This is a grantless operation.
+func (a *API) RotateApplicationClientSecret() error {
+	_, err := apis.NewCall[types.Nil](http.MethodPost, pathPrefix+"/clientSecret").
+		WithGrantlessScope(constants.ScopeClientCredentialRotation).
+		WithParseErrorListOnError().
+		WithRateLimit(0.0167, time.Second).
+		Execute(a.httpClient)
+	return err
+}
@@ -0,0 +1,42 @@
+package applications
+
+import (
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/notifications"
+)
+
+// NewSecretNotification The APPLICATION_OAUTH_CLIENT_NEW_SECRET notification.
+type NewSecretNotification struct {
+	NotificationVersion  string                         `json:"notificationVersion"`
+	NotificationType     notifications.NotificationType `json:"notificationType"`
+	PayloadVersion       string                         `json:"payloadVersion"`
+	EventTime            *time.Time                     `json:"eventTime,omitempty"`
+	NotificationMetadata NotificationMetadata           `json:"notificationMetadata"`
+	Payload              NewSecretPayload               `json:"payload"`
+}
+
+// NotificationMetadata Metadata of a notification.
+type NotificationMetadata struct {
+	ApplicationID  string     `json:"applicationId"`
+	SubscriptionID string     `json:"subscriptionId"`
+	PublishTime    *time.Time `json:"publishTime,omitempty"`
+	NotificationID string     `json:"notificationId"`
+}
+
+// NewSecretPayload The payload of the APPLICATION_OAUTH_CLIENT_NEW_SECRET notification.
+type NewSecretPayload struct {
+	ApplicationOAuthClientNewSecret ApplicationOAuthClientNewSecret `json:"applicationOAuthClientNewSecret"`
+}
+
+// ApplicationOAuthClientNewSecret The new client secret of the application.
+type ApplicationOAuthClientNewSecret struct {
+	// The client identifier of the application.
+	ClientID string `json:"clientId"`
+	// The new client secret.
+	NewClientSecret string `json:"newClientSecret"`
+	// The expiry time of the new client secret.
+	NewClientSecretExpiryTime *time.Time `json:"newClientSecretExpiryTime,omitempty"`
+	// The time after which the old client secret is not accepted anymore.
+	OldClientSecretExpiryTime *time.Time `json:"oldClientSecretExpiryTime,omitempty"`
+}
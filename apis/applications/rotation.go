@@ -0,0 +1,109 @@
+package applications
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/notifications"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+)
+
+type ClientSecretRotatorConfig struct {
+	ClientID     string
+	ClientSecret string
+	// OnNewSecret is called with every new secret before it is used, e.g. to write it to a vault.
+	// If it fails, the new secret is discarded.
+	OnNewSecret func(secret ApplicationOAuthClientNewSecret) error
+}
+
+// ClientSecretRotator holds the current client secret of an application and replaces it with the
+// secret of an APPLICATION_OAUTH_CLIENT_NEW_SECRET notification. Clients created with its
+// CredentialsProvider use the new secret for their next token request without a restart.
+type ClientSecretRotator struct {
+	clientID    string
+	onNewSecret func(secret ApplicationOAuthClientNewSecret) error
+
+	mu        sync.RWMutex
+	secret    string
+	expiresAt *time.Time
+}
+
+func NewClientSecretRotator(config ClientSecretRotatorConfig) (*ClientSecretRotator, error) {
+	if config.ClientID == "" || config.ClientSecret == "" {
+		return nil, errors.New("clientID and clientSecret are required")
+	}
+	return &ClientSecretRotator{
+		clientID:    config.ClientID,
+		secret:      config.ClientSecret,
+		onNewSecret: config.OnNewSecret,
+	}, nil
+}
+
+// ClientSecret returns the current client secret.
+func (r *ClientSecretRotator) ClientSecret() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.secret
+}
+
+// ExpiresAt returns the expiry of the current client secret if it is known, i.e. after the first rotation.
+func (r *ClientSecretRotator) ExpiresAt() *time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.expiresAt
+}
+
+// CredentialsProvider returns a provider for the credentials of a seller which always contain
+// the current client secret.
+func (r *ClientSecretRotator) CredentialsProvider(refreshToken string) httpx.CredentialsProvider {
+	return httpx.CredentialsProviderFunc(func() (*httpx.Credentials, error) {
+		return &httpx.Credentials{
+			ClientID:     r.clientID,
+			ClientSecret: r.ClientSecret(),
+			RefreshToken: refreshToken,
+		}, nil
+	})
+}
+
+// Rotate requests a new client secret. The rotation is completed when the notification is passed
+// to HandleNotification, the current secret stays valid until then.
+func (r *ClientSecretRotator) Rotate(api *API) error {
+	return api.RotateApplicationClientSecret()
+}
+
+// HandleNotification parses an APPLICATION_OAUTH_CLIENT_NEW_SECRET notification, e.g. the body of
+// an SQS message, and replaces the current client secret.
+func (r *ClientSecretRotator) HandleNotification(body []byte) error {
+	notification := &NewSecretNotification{}
+	if err := json.Unmarshal(body, notification); err != nil {
+		return fmt.Errorf("failed to parse notification: %w", err)
+	}
+	if notification.NotificationType != notifications.NotificationTypeApplicationOAuthClientNewSecret {
+		return fmt.Errorf("unexpected notification type %s", notification.NotificationType)
+	}
+	return r.SetNewSecret(notification.Payload.ApplicationOAuthClientNewSecret)
+}
+
+// SetNewSecret replaces the current client secret.
+func (r *ClientSecretRotator) SetNewSecret(secret ApplicationOAuthClientNewSecret) error {
+	if secret.ClientID != r.clientID {
+		return fmt.Errorf("new secret is for client %s, expected %s", secret.ClientID, r.clientID)
+	}
+	if secret.NewClientSecret == "" {
+		return errors.New("new secret is empty")
+	}
+	if r.onNewSecret != nil {
+		if err := r.onNewSecret(secret); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secret = secret.NewClientSecret
+	r.expiresAt = secret.NewClientSecretExpiryTime
+	return nil
+}
@@ -0,0 +1,91 @@
+package applications
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const newSecretNotification = `{
+  "notificationVersion": "1.0",
+  "notificationType": "APPLICATION_OAUTH_CLIENT_NEW_SECRET",
+  "payloadVersion": "2023-12-13",
+  "eventTime": "2024-01-10T12:00:00.000Z",
+  "notificationMetadata": {
+    "applicationId": "amzn1.sp.solution.test",
+    "subscriptionId": "subscription-id",
+    "publishTime": "2024-01-10T12:00:01.000Z",
+    "notificationId": "notification-id"
+  },
+  "payload": {
+    "applicationOAuthClientNewSecret": {
+      "clientId": "%s",
+      "newClientSecret": "newSecret",
+      "newClientSecretExpiryTime": "2024-07-08T12:00:00.000Z",
+      "oldClientSecretExpiryTime": "2024-01-17T12:00:00.000Z"
+    }
+  }
+}`
+
+func TestClientSecretRotator_HandleNotification(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		onNewSecret func(secret ApplicationOAuthClientNewSecret) error
+		wantErr     bool
+		wantSecret  string
+	}{
+		{
+			name:       "New secret",
+			body:       fmt.Sprintf(newSecretNotification, "clientID"),
+			wantSecret: "newSecret",
+		},
+		{
+			name:       "Secret of another client",
+			body:       fmt.Sprintf(newSecretNotification, "otherClientID"),
+			wantErr:    true,
+			wantSecret: "oldSecret",
+		},
+		{
+			name:       "Other notification type",
+			body:       `{"notificationType":"ORDER_CHANGE"}`,
+			wantErr:    true,
+			wantSecret: "oldSecret",
+		},
+		{
+			name: "Secret is not used if it cannot be saved",
+			body: fmt.Sprintf(newSecretNotification, "clientID"),
+			onNewSecret: func(ApplicationOAuthClientNewSecret) error {
+				return errors.New("vault unavailable")
+			},
+			wantErr:    true,
+			wantSecret: "oldSecret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rotator, err := NewClientSecretRotator(ClientSecretRotatorConfig{
+				ClientID:     "clientID",
+				ClientSecret: "oldSecret",
+				OnNewSecret:  tt.onNewSecret,
+			})
+			require.NoError(t, err)
+			provider := rotator.CredentialsProvider("refreshToken")
+
+			err = rotator.HandleNotification([]byte(tt.body))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			credentials, err := provider.GetCredentials()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSecret, credentials.ClientSecret)
+			assert.Equal(t, "refreshToken", credentials.RefreshToken)
+		})
+	}
+}
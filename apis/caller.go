@@ -16,6 +16,20 @@ type HTTPClient interface {
 	GetEndpoint() constants.Endpoint
 	Close()
 }
+
+// RestrictedDataTokenProvider returns Restricted Data Tokens (RDTs) for restricted operations.
+// HTTPClients implementing it can acquire RDTs automatically, if an empty token is returned
+// the call is executed with the regular access token.
+type RestrictedDataTokenProvider interface {
+	GetRestrictedDataToken(method string, path string, dataElements []string) (string, error)
+}
+
+// GrantlessAccessTokenProvider returns access tokens of the client_credentials grant for grantless
+// operations, which are not called on behalf of a selling partner, e.g. notification destinations.
+type GrantlessAccessTokenProvider interface {
+	GetGrantlessAccessToken(scope constants.GrantlessScope) (string, error)
+}
+
 type CallResponse[responseBodyType any] struct {
 	Status       int
 	ResponseBody *responseBodyType
@@ -27,6 +41,10 @@ type Call[responseType any] struct {
 	QueryParams             url.Values
 	Body                    []byte
 	RestrictedDataToken     *string
+	IsRestricted            bool
+	RestrictedDataElements  []string
+	GrantlessScope          constants.GrantlessScope
+	GrantlessAccessToken    string
 	ParseErrorListOnError   bool
 	WaitDurationOnRateLimit time.Duration
 }
@@ -60,6 +78,22 @@ func (a *Call[responseType]) WithRestrictedDataToken(token *string) *Call[respon
 	return a
 }
 
+// WithRestrictedResource marks the call as restricted operation. If no RestrictedDataToken is passed,
+// the RDT for the method, path and dataElements of the call is requested from the HTTPClient,
+// if it implements RestrictedDataTokenProvider.
+func (a *Call[responseType]) WithRestrictedResource(dataElements ...string) *Call[responseType] {
+	a.IsRestricted = true
+	a.RestrictedDataElements = dataElements
+	return a
+}
+
+// WithGrantlessScope marks the call as grantless operation. It is executed with an access token
+// for the scope, which is requested from the HTTPClient, so it must implement GrantlessAccessTokenProvider.
+func (a *Call[responseType]) WithGrantlessScope(scope constants.GrantlessScope) *Call[responseType] {
+	a.GrantlessScope = scope
+	return a
+}
+
 func (a *Call[responseType]) WithParseErrorListOnError() *Call[responseType] {
 	a.ParseErrorListOnError = true
 	return a
@@ -101,6 +135,13 @@ func (a *Call[responseType]) Execute(httpClient HTTPClient) (*CallResponse[respo
 }
 
 func (a *Call[responseType]) execute(httpClient HTTPClient) (*http.Response, error) {
+	if err := a.acquireRestrictedDataToken(httpClient); err != nil {
+		return nil, err
+	}
+	if err := a.acquireGrantlessAccessToken(httpClient); err != nil {
+		return nil, err
+	}
+
 	for attempts := 0; attempts < constants.MaxRetryCountOnTooManyRequestsError; attempts++ {
 		req, err := a.createNewRequest(httpClient.GetEndpoint())
 		if err != nil {
@@ -123,6 +164,40 @@ func (a *Call[responseType]) execute(httpClient HTTPClient) (*http.Response, err
 	return nil, ErrMaxRetryCountReached
 }
 
+func (a *Call[responseType]) acquireRestrictedDataToken(httpClient HTTPClient) error {
+	if !a.IsRestricted || a.RestrictedDataToken != nil {
+		return nil
+	}
+	provider, ok := httpClient.(RestrictedDataTokenProvider)
+	if !ok {
+		return nil
+	}
+
+	token, err := provider.GetRestrictedDataToken(a.Method, a.URL, a.RestrictedDataElements)
+	if err != nil {
+		return fmt.Errorf("acquiring restricted data token for %s %s failed: %w", a.Method, a.URL, err)
+	}
+	a.RestrictedDataToken = &token
+	return nil
+}
+
+func (a *Call[responseType]) acquireGrantlessAccessToken(httpClient HTTPClient) error {
+	if a.GrantlessScope == "" {
+		return nil
+	}
+	provider, ok := httpClient.(GrantlessAccessTokenProvider)
+	if !ok {
+		return fmt.Errorf("grantless operation %s %s requires an HTTPClient implementing GrantlessAccessTokenProvider", a.Method, a.URL)
+	}
+
+	token, err := provider.GetGrantlessAccessToken(a.GrantlessScope)
+	if err != nil {
+		return fmt.Errorf("acquiring grantless access token for scope %s failed: %w", a.GrantlessScope, err)
+	}
+	a.GrantlessAccessToken = token
+	return nil
+}
+
 func (a *Call[responseType]) createNewRequest(endpoint constants.Endpoint) (*http.Request, error) {
 	callURL, err := url.Parse(string(endpoint) + a.URL)
 	if err != nil {
@@ -134,6 +209,8 @@ func (a *Call[responseType]) createNewRequest(endpoint constants.Endpoint) (*htt
 	if err == nil {
 		if a.RestrictedDataToken != nil && *a.RestrictedDataToken != "" {
 			req.Header.Add(constants.AccessTokenHeader, *a.RestrictedDataToken)
+		} else if a.GrantlessAccessToken != "" {
+			req.Header.Add(constants.AccessTokenHeader, a.GrantlessAccessToken)
 		}
 	}
 	return req, err
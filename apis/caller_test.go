@@ -305,3 +305,104 @@ func Test_calcWaitTimeByRateLimit(t *testing.T) {
 		})
 	}
 }
+
+type dummyRDTHTTPClient struct {
+	dummyHTTPClient
+	rdtRequests []string
+}
+
+func (r *dummyRDTHTTPClient) GetRestrictedDataToken(method string, path string, dataElements []string) (string, error) {
+	r.rdtRequests = append(r.rdtRequests, method+" "+path+" "+MapToCommaString(dataElements))
+	return "AUTO-RDT", nil
+}
+
+func Test_call_ExecuteWithRestrictedResource(t *testing.T) {
+	explicitRDT := "EXPLICIT-RDT"
+	tests := []struct {
+		name            string
+		restricted      bool
+		token           *string
+		wantHeader      string
+		wantRDTRequests []string
+	}{
+		{
+			name:            "Restricted call acquires RDT",
+			restricted:      true,
+			wantHeader:      "AUTO-RDT",
+			wantRDTRequests: []string{"GET /orders/4711 buyerInfo,shippingAddress"},
+		},
+		{
+			name:       "Explicit RDT is preferred",
+			restricted: true,
+			token:      &explicitRDT,
+			wantHeader: "EXPLICIT-RDT",
+		},
+		{
+			name:       "Unrestricted call does not acquire RDT",
+			wantHeader: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockResp, err := mockResponse(&CallResponse[dummyBody]{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := &dummyRDTHTTPClient{dummyHTTPClient: dummyHTTPClient{endpoint: constants.Europe, resp: mockResp}}
+
+			call := NewCall[dummyBody](http.MethodGet, "/orders/4711").WithRestrictedDataToken(tt.token)
+			if tt.restricted {
+				call = call.WithRestrictedResource("buyerInfo", "shippingAddress")
+			}
+			if _, err = call.Execute(client); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := client.req.Header.Get(constants.AccessTokenHeader); got != tt.wantHeader {
+				t.Errorf("Execute(): AccessTokenHeader different. got = '%v', want '%v'", got, tt.wantHeader)
+			}
+			if !reflect.DeepEqual(client.rdtRequests, tt.wantRDTRequests) {
+				t.Errorf("Execute(): RDT requests different. got = '%v', want '%v'", client.rdtRequests, tt.wantRDTRequests)
+			}
+		})
+	}
+}
+
+type dummyGrantlessHTTPClient struct {
+	dummyHTTPClient
+	scopes []constants.GrantlessScope
+}
+
+func (r *dummyGrantlessHTTPClient) GetGrantlessAccessToken(scope constants.GrantlessScope) (string, error) {
+	r.scopes = append(r.scopes, scope)
+	return "GRANTLESS-TOKEN", nil
+}
+
+func Test_call_ExecuteWithGrantlessScope(t *testing.T) {
+	mockResp, err := mockResponse(&CallResponse[dummyBody]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &dummyGrantlessHTTPClient{dummyHTTPClient: dummyHTTPClient{endpoint: constants.Europe, resp: mockResp}}
+
+	_, err = NewCall[dummyBody](http.MethodGet, "/notifications/v1/destinations").
+		WithGrantlessScope(constants.ScopeNotifications).
+		Execute(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.req.Header.Get(constants.AccessTokenHeader); got != "GRANTLESS-TOKEN" {
+		t.Errorf("Execute(): AccessTokenHeader different. got = '%v', want 'GRANTLESS-TOKEN'", got)
+	}
+	if !reflect.DeepEqual(client.scopes, []constants.GrantlessScope{constants.ScopeNotifications}) {
+		t.Errorf("Execute(): scopes different. got = '%v'", client.scopes)
+	}
+
+	// clients without support for grantless operations fail instead of using the seller's token
+	_, err = NewCall[dummyBody](http.MethodGet, "/notifications/v1/destinations").
+		WithGrantlessScope(constants.ScopeNotifications).
+		Execute(&dummyHTTPClient{endpoint: constants.Europe, resp: mockResp})
+	if err == nil {
+		t.Error("Execute(): expected error for HTTPClient without GrantlessAccessTokenProvider")
+	}
+}
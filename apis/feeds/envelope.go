@@ -0,0 +1,150 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+const (
+	// FeedTypeOrderFulfillment confirms the shipment of merchant-fulfilled orders.
+	FeedTypeOrderFulfillment = "POST_ORDER_FULFILLMENT_DATA"
+	// FeedTypePaymentAdjustment refunds or adjusts merchant-fulfilled orders.
+	FeedTypePaymentAdjustment = "POST_PAYMENT_ADJUSTMENT_DATA"
+
+	// ContentTypeXML is the content type used with CreateFeedDocument for XML feeds.
+	ContentTypeXML = "text/xml; charset=UTF-8"
+
+	envelopeDocumentVersion = "1.01"
+)
+
+// EnvelopeHeader is the header of every XML feed and processing report.
+type EnvelopeHeader struct {
+	DocumentVersion    string `xml:"DocumentVersion"`
+	MerchantIdentifier string `xml:"MerchantIdentifier"`
+}
+
+type envelope struct {
+	XMLName                   xml.Name       `xml:"AmazonEnvelope"`
+	XSI                       string         `xml:"xmlns:xsi,attr"`
+	NoNamespaceSchemaLocation string         `xml:"xsi:noNamespaceSchemaLocation,attr"`
+	Header                    EnvelopeHeader `xml:"Header"`
+	MessageType               string         `xml:"MessageType"`
+	Messages                  []feedMessage  `xml:"Message"`
+}
+
+type feedMessage struct {
+	MessageID        int               `xml:"MessageID"`
+	OrderFulfillment *OrderFulfillment `xml:"OrderFulfillment,omitempty"`
+	OrderAdjustment  *OrderAdjustment  `xml:"OrderAdjustment,omitempty"`
+}
+
+func marshalEnvelope(merchantID string, messageType string, messages []feedMessage) ([]byte, error) {
+	if merchantID == "" {
+		return nil, errors.New("merchantID is required")
+	}
+	if len(messages) == 0 {
+		return nil, errors.New("feed does not contain any messages")
+	}
+
+	body, err := xml.MarshalIndent(envelope{
+		XSI:                       "http://www.w3.org/2001/XMLSchema-instance",
+		NoNamespaceSchemaLocation: "amzn-envelope.xsd",
+		Header: EnvelopeHeader{
+			DocumentVersion:    envelopeDocumentVersion,
+			MerchantIdentifier: merchantID,
+		},
+		MessageType: messageType,
+		Messages:    messages,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ProcessingReport is the result document of an XML feed.
+type ProcessingReport struct {
+	Header                EnvelopeHeader
+	DocumentTransactionID string
+	// StatusCode is "Complete" once all messages have been processed.
+	StatusCode        string
+	ProcessingSummary ProcessingSummary
+	Results           []ProcessingResult
+}
+
+// ProcessingSummary counts the processed messages of a feed.
+type ProcessingSummary struct {
+	MessagesProcessed   int `xml:"MessagesProcessed"`
+	MessagesSuccessful  int `xml:"MessagesSuccessful"`
+	MessagesWithError   int `xml:"MessagesWithError"`
+	MessagesWithWarning int `xml:"MessagesWithWarning"`
+}
+
+// ProcessingResult describes an error or warning for a single message of a feed.
+type ProcessingResult struct {
+	// MessageID refers to the MessageID of the submitted message.
+	MessageID int `xml:"MessageID"`
+	// ResultCode is either "Error" or "Warning".
+	ResultCode        string `xml:"ResultCode"`
+	ResultMessageCode int    `xml:"ResultMessageCode"`
+	ResultDescription string `xml:"ResultDescription"`
+	AdditionalInfo    struct {
+		AmazonOrderID string `xml:"AmazonOrderID"`
+		SKU           string `xml:"SKU"`
+	} `xml:"AdditionalInfo"`
+}
+
+// IsError returns true if the result marks the message as failed.
+func (r *ProcessingResult) IsError() bool {
+	return r.ResultCode == "Error"
+}
+
+// HasErrors returns true if at least one message of the feed failed.
+func (r *ProcessingReport) HasErrors() bool {
+	return r.ProcessingSummary.MessagesWithError > 0
+}
+
+// Errors returns all results with ResultCode "Error".
+func (r *ProcessingReport) Errors() []ProcessingResult {
+	var errs []ProcessingResult
+	for _, result := range r.Results {
+		if result.IsError() {
+			errs = append(errs, result)
+		}
+	}
+	return errs
+}
+
+// ParseProcessingReport parses the result document of an XML feed,
+// e.g. of POST_ORDER_FULFILLMENT_DATA or POST_PAYMENT_ADJUSTMENT_DATA.
+func ParseProcessingReport(data []byte) (*ProcessingReport, error) {
+	var parsed struct {
+		XMLName     xml.Name       `xml:"AmazonEnvelope"`
+		Header      EnvelopeHeader `xml:"Header"`
+		MessageType string         `xml:"MessageType"`
+		Message     struct {
+			ProcessingReport struct {
+				DocumentTransactionID string             `xml:"DocumentTransactionID"`
+				StatusCode            string             `xml:"StatusCode"`
+				ProcessingSummary     ProcessingSummary  `xml:"ProcessingSummary"`
+				Results               []ProcessingResult `xml:"Result"`
+			} `xml:"ProcessingReport"`
+		} `xml:"Message"`
+	}
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed.MessageType != "ProcessingReport" {
+		return nil, fmt.Errorf("unexpected message type %q, expected ProcessingReport", parsed.MessageType)
+	}
+
+	report := parsed.Message.ProcessingReport
+	return &ProcessingReport{
+		Header:                parsed.Header,
+		DocumentTransactionID: report.DocumentTransactionID,
+		StatusCode:            report.StatusCode,
+		ProcessingSummary:     report.ProcessingSummary,
+		Results:               report.Results,
+	}, nil
+}
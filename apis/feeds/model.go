@@ -67,6 +67,11 @@ type CreateFeedSpecification struct {
 	FeedOptions *map[string]string `json:"feedOptions,omitempty"`
 }
 
+// GetMarketplaceIDs implements apis.MarketplaceScoped.
+func (s *CreateFeedSpecification) GetMarketplaceIDs() []constants.MarketplaceID {
+	return s.MarketplaceIDs
+}
+
 // CreateFeedDocumentSpecification specifies the content type for the createFeedDocument operation.
 type CreateFeedDocumentSpecification struct {
 	// The content type of the feed.
@@ -98,6 +103,11 @@ type GetFeedsRequestFilter struct {
 	NextToken string `json:"nextToken,omitempty"`
 }
 
+// GetMarketplaceIDs implements apis.MarketplaceScoped.
+func (f *GetFeedsRequestFilter) GetMarketplaceIDs() []constants.MarketplaceID {
+	return f.MarketplaceIDs
+}
+
 func (f *GetFeedsRequestFilter) GetQuery() url.Values {
 	q := url.Values{}
 
@@ -0,0 +1,212 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	"github.com/fond-of-vertigo/amazon-sp-api/internal/utils"
+)
+
+// knownCarrierCodes are carriers which can be passed as CarrierCode. All other
+// carriers are passed as CarrierName.
+var knownCarrierCodes = utils.NewSet(
+	"Blue Package", "USPS", "UPS", "UPSMI", "FedEx", "DHL", "DHL Global Mail", "Fastway", "UPS Mail Innovations",
+	"Lasership", "Royal Mail", "FedEx SmartPost", "OSM", "OnTrac", "Streamlite", "Newgistics", "Canada Post",
+	"City Link", "GLS", "GO!", "Hermes Logistik Gruppe", "Parcelforce", "TNT", "Target", "SagawaExpress",
+	"NipponExpress", "YamatoTransport", "DHL Freight", "Endopack", "Chronopost", "Coliposte", "Correos",
+	"Deutsche Post", "DPD", "Seur", "SDA", "Poste Italiane", "La Poste", "Yodel", "Hermes", "UPS Freight",
+	"Australia Post", "Japan Post", "BRT", "Nacex", "PostNL", "DHL eCommerce", "Amazon Shipping",
+)
+
+// OrderFulfillment confirms the shipment of (a part of) an order.
+type OrderFulfillment struct {
+	AmazonOrderID   string                 `xml:"AmazonOrderID"`
+	FulfillmentDate string                 `xml:"FulfillmentDate"`
+	FulfillmentData FulfillmentData        `xml:"FulfillmentData"`
+	Items           []OrderFulfillmentItem `xml:"Item"`
+	ShipFromAddress *ShipFromAddress       `xml:"ShipFromAddress,omitempty"`
+}
+
+// FulfillmentData contains the carrier and tracking information of a shipment.
+type FulfillmentData struct {
+	// CarrierCode is set if the carrier is known by Amazon, otherwise CarrierName is used.
+	CarrierCode           string `xml:"CarrierCode,omitempty"`
+	CarrierName           string `xml:"CarrierName,omitempty"`
+	ShippingMethod        string `xml:"ShippingMethod,omitempty"`
+	ShipperTrackingNumber string `xml:"ShipperTrackingNumber,omitempty"`
+}
+
+// OrderFulfillmentItem is a shipped order item and its quantity.
+type OrderFulfillmentItem struct {
+	AmazonOrderItemCode string `xml:"AmazonOrderItemCode"`
+	Quantity            int    `xml:"Quantity"`
+}
+
+// ShipFromAddress is the address the package was shipped from.
+type ShipFromAddress struct {
+	Name            string `xml:"Name,omitempty"`
+	AddressFieldOne string `xml:"AddressFieldOne,omitempty"`
+	AddressFieldTwo string `xml:"AddressFieldTwo,omitempty"`
+	City            string `xml:"City,omitempty"`
+	StateOrRegion   string `xml:"StateOrRegion,omitempty"`
+	PostalCode      string `xml:"PostalCode,omitempty"`
+	CountryCode     string `xml:"CountryCode"`
+}
+
+// NewFulfillmentData returns the FulfillmentData for the given carrier. Carriers known by Amazon
+// are set as CarrierCode, all others as CarrierName.
+func NewFulfillmentData(carrier, shippingMethod, trackingNumber string) FulfillmentData {
+	data := FulfillmentData{
+		ShippingMethod:        shippingMethod,
+		ShipperTrackingNumber: trackingNumber,
+	}
+	if knownCarrierCodes.Has(carrier) {
+		data.CarrierCode = carrier
+	} else {
+		data.CarrierName = carrier
+	}
+	return data
+}
+
+// OrderFulfillmentFeed builds a POST_ORDER_FULFILLMENT_DATA feed document.
+type OrderFulfillmentFeed struct {
+	merchantID string
+	messages   []feedMessage
+}
+
+// NewOrderFulfillmentFeed returns an empty feed for the given merchant (seller) ID.
+func NewOrderFulfillmentFeed(merchantID string) *OrderFulfillmentFeed {
+	return &OrderFulfillmentFeed{merchantID: merchantID}
+}
+
+// AddPackage adds a shipping confirmation for the given package of the order. The carrier,
+// tracking number, shipping service, ship time and items are taken from the package.
+// It returns the MessageID, which is referenced in the ProcessingReport.
+func (f *OrderFulfillmentFeed) AddPackage(order orders.Order, pkg orders.OrderPackage) (int, error) {
+	if pkg.ShipTime == nil {
+		return 0, fmt.Errorf("package %s of order %s has no shipTime", pkg.PackageReferenceID, order.OrderID)
+	}
+	if pkg.Carrier == "" {
+		return 0, fmt.Errorf("package %s of order %s has no carrier", pkg.PackageReferenceID, order.OrderID)
+	}
+	if len(pkg.PackageItems) == 0 {
+		return 0, fmt.Errorf("package %s of order %s has no items", pkg.PackageReferenceID, order.OrderID)
+	}
+
+	items := make([]OrderFulfillmentItem, len(pkg.PackageItems))
+	for i, item := range pkg.PackageItems {
+		if err := checkOrderItem(order, item.OrderItemID); err != nil {
+			return 0, err
+		}
+		items[i] = OrderFulfillmentItem{
+			AmazonOrderItemCode: item.OrderItemID,
+			Quantity:            item.Quantity,
+		}
+	}
+
+	fulfillment := OrderFulfillment{
+		AmazonOrderID:   order.OrderID,
+		FulfillmentDate: pkg.ShipTime.Format(time.RFC3339),
+		FulfillmentData: NewFulfillmentData(pkg.Carrier, pkg.ShippingService, pkg.TrackingNumber),
+		Items:           items,
+	}
+	if addr := pkg.ShipFromAddress; addr != nil {
+		fulfillment.ShipFromAddress = &ShipFromAddress{
+			Name:            addr.Name,
+			AddressFieldOne: addr.AddressLine1,
+			AddressFieldTwo: addr.AddressLine2,
+			City:            addr.City,
+			StateOrRegion:   addr.StateOrRegion,
+			PostalCode:      addr.PostalCode,
+			CountryCode:     addr.CountryCode,
+		}
+	}
+	return f.AddOrderFulfillment(fulfillment)
+}
+
+// AddOrderItems adds a shipping confirmation for the full ordered quantity of the given order items,
+// which must be items of the order.
+func (f *OrderFulfillmentFeed) AddOrderItems(order orders.Order, items []orders.OrderItem, data FulfillmentData, shipTime time.Time) (int, error) {
+	if len(items) == 0 {
+		return 0, fmt.Errorf("no items of order %s passed", order.OrderID)
+	}
+	for _, item := range items {
+		if err := checkOrderItem(order, item.OrderItemID); err != nil {
+			return 0, err
+		}
+	}
+
+	fulfillment := OrderFulfillment{
+		AmazonOrderID:   order.OrderID,
+		FulfillmentDate: shipTime.Format(time.RFC3339),
+		FulfillmentData: data,
+		Items:           make([]OrderFulfillmentItem, len(items)),
+	}
+	for i, item := range items {
+		fulfillment.Items[i] = OrderFulfillmentItem{
+			AmazonOrderItemCode: item.OrderItemID,
+			Quantity:            item.QuantityOrdered,
+		}
+	}
+	return f.AddOrderFulfillment(fulfillment)
+}
+
+// AddOrderFulfillment validates and adds the given message to the feed.
+func (f *OrderFulfillmentFeed) AddOrderFulfillment(fulfillment OrderFulfillment) (int, error) {
+	if err := fulfillment.validate(); err != nil {
+		return 0, err
+	}
+	messageID := len(f.messages) + 1
+	f.messages = append(f.messages, feedMessage{
+		MessageID:        messageID,
+		OrderFulfillment: &fulfillment,
+	})
+	return messageID, nil
+}
+
+// Len returns the number of messages in the feed.
+func (f *OrderFulfillmentFeed) Len() int {
+	return len(f.messages)
+}
+
+// Marshal returns the XML feed document, which can be uploaded with the ContentTypeXML.
+func (f *OrderFulfillmentFeed) Marshal() ([]byte, error) {
+	return marshalEnvelope(f.merchantID, "OrderFulfillment", f.messages)
+}
+
+func (o *OrderFulfillment) validate() error {
+	if o.AmazonOrderID == "" {
+		return errors.New("amazonOrderID is required")
+	}
+	if o.FulfillmentDate == "" {
+		return fmt.Errorf("fulfillmentDate of order %s is required", o.AmazonOrderID)
+	}
+	if o.FulfillmentData.CarrierCode == "" && o.FulfillmentData.CarrierName == "" {
+		return fmt.Errorf("carrier of order %s is required", o.AmazonOrderID)
+	}
+	for _, item := range o.Items {
+		if item.AmazonOrderItemCode == "" {
+			return fmt.Errorf("order item of order %s has no amazonOrderItemCode", o.AmazonOrderID)
+		}
+		if item.Quantity < 1 {
+			return fmt.Errorf("quantity of order item %s must be positive", item.AmazonOrderItemCode)
+		}
+	}
+	return nil
+}
+
+// checkOrderItem returns an error if the order item is not part of the order. The order must
+// contain its items, e.g. as returned by GetOrder.
+func checkOrderItem(order orders.Order, orderItemID string) error {
+	if len(order.OrderItems) == 0 {
+		return fmt.Errorf("order %s has no order items to check order item %s against", order.OrderID, orderItemID)
+	}
+	for _, item := range order.OrderItems {
+		if item.OrderItemID == orderItemID {
+			return nil
+		}
+	}
+	return fmt.Errorf("order item %s is not part of order %s", orderItemID, order.OrderID)
+}
@@ -0,0 +1,203 @@
+package feeds
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderFulfillmentFeed_AddPackage(t *testing.T) {
+	shipTime := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	order := orders.Order{
+		OrderID:    "123-1234567-1234567",
+		OrderItems: []orders.OrderItem{{OrderItemID: "11111"}, {OrderItemID: "22222"}},
+	}
+	tests := []struct {
+		name     string
+		pkg      orders.OrderPackage
+		wantErr  bool
+		contains []string
+	}{
+		{
+			name: "Known carrier",
+			pkg: orders.OrderPackage{
+				Carrier:         "DHL",
+				ShippingService: "Paket",
+				TrackingNumber:  "00340434161094042557",
+				ShipTime:        &shipTime,
+				PackageItems:    []orders.PackageItem{{OrderItemID: "11111", Quantity: 2}},
+			},
+			contains: []string{
+				"<AmazonOrderID>123-1234567-1234567</AmazonOrderID>",
+				"<FulfillmentDate>2026-01-02T15:04:05Z</FulfillmentDate>",
+				"<CarrierCode>DHL</CarrierCode>",
+				"<ShippingMethod>Paket</ShippingMethod>",
+				"<ShipperTrackingNumber>00340434161094042557</ShipperTrackingNumber>",
+				"<AmazonOrderItemCode>11111</AmazonOrderItemCode>",
+				"<Quantity>2</Quantity>",
+			},
+		},
+		{
+			name: "Unknown carrier is passed as name",
+			pkg: orders.OrderPackage{
+				Carrier:      "Local Courier",
+				ShipTime:     &shipTime,
+				PackageItems: []orders.PackageItem{{OrderItemID: "22222", Quantity: 1}},
+			},
+			contains: []string{"<CarrierName>Local Courier</CarrierName>"},
+		},
+		{
+			name: "Unknown order item",
+			pkg: orders.OrderPackage{
+				Carrier:      "DHL",
+				ShipTime:     &shipTime,
+				PackageItems: []orders.PackageItem{{OrderItemID: "33333", Quantity: 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing ship time",
+			pkg: orders.OrderPackage{
+				Carrier:      "DHL",
+				PackageItems: []orders.PackageItem{{OrderItemID: "11111", Quantity: 1}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := NewOrderFulfillmentFeed("M_EXAMPLE")
+			messageID, err := feed.AddPackage(order, tt.pkg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, 0, feed.Len())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, messageID)
+
+			doc, err := feed.Marshal()
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(doc), "<?xml"))
+			assert.Contains(t, string(doc), "<MessageType>OrderFulfillment</MessageType>")
+			assert.Contains(t, string(doc), "<MerchantIdentifier>M_EXAMPLE</MerchantIdentifier>")
+			for _, c := range tt.contains {
+				assert.Contains(t, string(doc), c)
+			}
+		})
+	}
+}
+
+func TestOrderFulfillmentFeed_AddOrderItems(t *testing.T) {
+	shipTime := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	data := NewFulfillmentData("DHL", "Paket", "00340434161094042557")
+	order := orders.Order{
+		OrderID:    "123-1234567-1234567",
+		OrderItems: []orders.OrderItem{{OrderItemID: "11111", QuantityOrdered: 2}, {OrderItemID: "22222", QuantityOrdered: 1}},
+	}
+	tests := []struct {
+		name    string
+		order   orders.Order
+		items   []orders.OrderItem
+		wantErr string
+	}{
+		{name: "Items of the order", order: order, items: order.OrderItems[:1]},
+		{name: "Unknown order item", order: order, items: []orders.OrderItem{{OrderItemID: "33333", QuantityOrdered: 1}}, wantErr: "order item 33333 is not part of order"},
+		{name: "Order without items", order: orders.Order{OrderID: order.OrderID}, items: order.OrderItems, wantErr: "has no order items"},
+		{name: "No items", order: order, wantErr: "no items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := NewOrderFulfillmentFeed("M_EXAMPLE")
+			_, err := feed.AddOrderItems(tt.order, tt.items, data, shipTime)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, 0, feed.Len())
+				return
+			}
+			assert.NoError(t, err)
+
+			doc, err := feed.Marshal()
+			assert.NoError(t, err)
+			assert.Contains(t, string(doc), "<AmazonOrderItemCode>11111</AmazonOrderItemCode>")
+			assert.Contains(t, string(doc), "<Quantity>2</Quantity>")
+		})
+	}
+}
+
+func TestPaymentAdjustmentFeed_AddItem(t *testing.T) {
+	order := orders.Order{
+		OrderID:    "123-1234567-1234567",
+		OrderItems: []orders.OrderItem{{OrderItemID: "11111"}, {OrderItemID: "22222"}},
+	}
+	feed := NewPaymentAdjustmentFeed("M_EXAMPLE")
+
+	first, err := feed.AddItem(order, order.OrderItems[0], AdjustmentReasonCustomerReturn,
+		NewPriceComponent(PriceComponentPrincipal, orders.Money{Amount: apis.MustParseAmount("19.99"), CurrencyCode: "EUR"}))
+	assert.NoError(t, err)
+	second, err := feed.AddItem(order, order.OrderItems[1], AdjustmentReasonCustomerReturn,
+		NewPriceComponent(PriceComponentShipping, orders.Money{Amount: apis.MustParseAmount("4.90"), CurrencyCode: "EUR"}))
+	assert.NoError(t, err)
+	assert.Equal(t, first, second, "items of the same order must share a message")
+
+	_, err = feed.AddItem(order, order.OrderItems[0], AdjustmentReasonCustomerReturn)
+	assert.Error(t, err, "adjustment without components")
+
+	doc, err := feed.Marshal()
+	assert.NoError(t, err)
+	assert.Contains(t, string(doc), "<MessageType>OrderAdjustment</MessageType>")
+	assert.Contains(t, string(doc), `<Amount currency="EUR">19.99</Amount>`)
+	assert.Equal(t, 2, strings.Count(string(doc), "<AdjustedItem>"))
+}
+
+func TestParseProcessingReport(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<AmazonEnvelope xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:noNamespaceSchemaLocation="amzn-envelope.xsd">
+  <Header>
+    <DocumentVersion>1.02</DocumentVersion>
+    <MerchantIdentifier>M_EXAMPLE</MerchantIdentifier>
+  </Header>
+  <MessageType>ProcessingReport</MessageType>
+  <Message>
+    <MessageID>1</MessageID>
+    <ProcessingReport>
+      <DocumentTransactionID>4711</DocumentTransactionID>
+      <StatusCode>Complete</StatusCode>
+      <ProcessingSummary>
+        <MessagesProcessed>2</MessagesProcessed>
+        <MessagesSuccessful>1</MessagesSuccessful>
+        <MessagesWithError>1</MessagesWithError>
+        <MessagesWithWarning>0</MessagesWithWarning>
+      </ProcessingSummary>
+      <Result>
+        <MessageID>2</MessageID>
+        <ResultCode>Error</ResultCode>
+        <ResultMessageCode>18028</ResultMessageCode>
+        <ResultDescription>The data you submitted is incomplete or invalid.</ResultDescription>
+        <AdditionalInfo>
+          <AmazonOrderID>123-1234567-1234567</AmazonOrderID>
+        </AdditionalInfo>
+      </Result>
+    </ProcessingReport>
+  </Message>
+</AmazonEnvelope>`
+
+	report, err := ParseProcessingReport([]byte(doc))
+	assert.NoError(t, err)
+	assert.Equal(t, "Complete", report.StatusCode)
+	assert.True(t, report.HasErrors())
+	assert.Equal(t, 2, report.ProcessingSummary.MessagesProcessed)
+	errs := report.Errors()
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, 2, errs[0].MessageID)
+		assert.Equal(t, 18028, errs[0].ResultMessageCode)
+		assert.Equal(t, "123-1234567-1234567", errs[0].AdditionalInfo.AmazonOrderID)
+	}
+
+	_, err = ParseProcessingReport([]byte("<AmazonEnvelope><MessageType>Inventory</MessageType></AmazonEnvelope>"))
+	assert.Error(t, err)
+}
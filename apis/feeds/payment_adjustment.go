@@ -0,0 +1,170 @@
+package feeds
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+)
+
+// AdjustmentReason is the reason for a payment adjustment.
+type AdjustmentReason string
+
+const (
+	AdjustmentReasonNoInventory               AdjustmentReason = "NoInventory"
+	AdjustmentReasonCustomerReturn            AdjustmentReason = "CustomerReturn"
+	AdjustmentReasonGeneralAdjustment         AdjustmentReason = "GeneralAdjustment"
+	AdjustmentReasonCouldNotShip              AdjustmentReason = "CouldNotShip"
+	AdjustmentReasonDifferentItem             AdjustmentReason = "DifferentItem"
+	AdjustmentReasonAbandoned                 AdjustmentReason = "Abandoned"
+	AdjustmentReasonCustomerCancel            AdjustmentReason = "CustomerCancel"
+	AdjustmentReasonPriceError                AdjustmentReason = "PriceError"
+	AdjustmentReasonProductOutOfStock         AdjustmentReason = "ProductOutofStock"
+	AdjustmentReasonCustomerAddressIncorrect  AdjustmentReason = "CustomerAddressIncorrect"
+	AdjustmentReasonExchange                  AdjustmentReason = "Exchange"
+	AdjustmentReasonOther                     AdjustmentReason = "Other"
+	AdjustmentReasonCarrierCreditDecision     AdjustmentReason = "CarrierCreditDecision"
+	AdjustmentReasonCarrierCoverageFailure    AdjustmentReason = "CarrierCoverageFailure"
+	AdjustmentReasonTransactionRecord         AdjustmentReason = "TransactionRecord"
+	AdjustmentReasonUndeliverable             AdjustmentReason = "Undeliverable"
+	AdjustmentReasonRefusedDelivery           AdjustmentReason = "RefusedDelivery"
+	AdjustmentReasonRiskAssessmentInformation AdjustmentReason = "RiskAssessmentInformationNotValid"
+)
+
+// PriceComponentType is the part of the item price which is adjusted.
+type PriceComponentType string
+
+const (
+	PriceComponentPrincipal        PriceComponentType = "Principal"
+	PriceComponentShipping         PriceComponentType = "Shipping"
+	PriceComponentTax              PriceComponentType = "Tax"
+	PriceComponentShippingTax      PriceComponentType = "ShippingTax"
+	PriceComponentRestockingFee    PriceComponentType = "RestockingFee"
+	PriceComponentRestockingFeeTax PriceComponentType = "RestockingFeeTax"
+	PriceComponentGiftWrap         PriceComponentType = "GiftWrap"
+	PriceComponentGiftWrapTax      PriceComponentType = "GiftWrapTax"
+	PriceComponentSurcharge        PriceComponentType = "Surcharge"
+	PriceComponentReturnShipping   PriceComponentType = "ReturnShipping"
+	PriceComponentGoodwill         PriceComponentType = "Goodwill"
+	PriceComponentExportCharge     PriceComponentType = "ExportCharge"
+	PriceComponentCOD              PriceComponentType = "COD"
+	PriceComponentCODTax           PriceComponentType = "CODTax"
+	PriceComponentOther            PriceComponentType = "Other"
+)
+
+// OrderAdjustment refunds or adjusts items of an order.
+type OrderAdjustment struct {
+	AmazonOrderID string         `xml:"AmazonOrderID"`
+	AdjustedItems []AdjustedItem `xml:"AdjustedItem"`
+}
+
+// AdjustedItem is a single adjusted order item.
+type AdjustedItem struct {
+	AmazonOrderItemCode  string           `xml:"AmazonOrderItemCode"`
+	AdjustmentReason     AdjustmentReason `xml:"AdjustmentReason"`
+	ItemPriceAdjustments []PriceComponent `xml:"ItemPriceAdjustments>Component"`
+}
+
+// PriceComponent is the amount to refund for a part of the item price.
+type PriceComponent struct {
+	Type   PriceComponentType `xml:"Type"`
+	Amount FeedAmount         `xml:"Amount"`
+}
+
+// FeedAmount is an amount with its currency as XML attribute.
+type FeedAmount struct {
+	Currency string `xml:"currency,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// NewPriceComponent returns the PriceComponent to refund the given money.
+func NewPriceComponent(componentType PriceComponentType, money orders.Money) PriceComponent {
+	return PriceComponent{
+		Type: componentType,
+		Amount: FeedAmount{
+			Currency: money.CurrencyCode,
+			Value:    money.Amount.String(),
+		},
+	}
+}
+
+// PaymentAdjustmentFeed builds a POST_PAYMENT_ADJUSTMENT_DATA feed document.
+type PaymentAdjustmentFeed struct {
+	merchantID string
+	messages   []feedMessage
+	// messageIDByOrder groups all adjusted items of an order into one message
+	messageIDByOrder map[string]int
+}
+
+// NewPaymentAdjustmentFeed returns an empty feed for the given merchant (seller) ID.
+func NewPaymentAdjustmentFeed(merchantID string) *PaymentAdjustmentFeed {
+	return &PaymentAdjustmentFeed{
+		merchantID:       merchantID,
+		messageIDByOrder: map[string]int{},
+	}
+}
+
+// AddItem adds an adjustment of the given order item. Adjustments of the same order are
+// grouped into one message. It returns the MessageID, which is referenced in the ProcessingReport.
+func (f *PaymentAdjustmentFeed) AddItem(order orders.Order, item orders.OrderItem, reason AdjustmentReason, components ...PriceComponent) (int, error) {
+	if order.OrderID == "" {
+		return 0, errors.New("amazonOrderID is required")
+	}
+	if err := checkOrderItem(order, item.OrderItemID); err != nil {
+		return 0, err
+	}
+
+	adjusted := AdjustedItem{
+		AmazonOrderItemCode:  item.OrderItemID,
+		AdjustmentReason:     reason,
+		ItemPriceAdjustments: components,
+	}
+	if err := adjusted.validate(); err != nil {
+		return 0, err
+	}
+
+	if messageID, ok := f.messageIDByOrder[order.OrderID]; ok {
+		adjustment := f.messages[messageID-1].OrderAdjustment
+		adjustment.AdjustedItems = append(adjustment.AdjustedItems, adjusted)
+		return messageID, nil
+	}
+
+	messageID := len(f.messages) + 1
+	f.messages = append(f.messages, feedMessage{
+		MessageID: messageID,
+		OrderAdjustment: &OrderAdjustment{
+			AmazonOrderID: order.OrderID,
+			AdjustedItems: []AdjustedItem{adjusted},
+		},
+	})
+	f.messageIDByOrder[order.OrderID] = messageID
+	return messageID, nil
+}
+
+// Len returns the number of messages in the feed.
+func (f *PaymentAdjustmentFeed) Len() int {
+	return len(f.messages)
+}
+
+// Marshal returns the XML feed document, which can be uploaded with the ContentTypeXML.
+func (f *PaymentAdjustmentFeed) Marshal() ([]byte, error) {
+	return marshalEnvelope(f.merchantID, "OrderAdjustment", f.messages)
+}
+
+func (a *AdjustedItem) validate() error {
+	if a.AmazonOrderItemCode == "" {
+		return errors.New("amazonOrderItemCode is required")
+	}
+	if a.AdjustmentReason == "" {
+		return fmt.Errorf("adjustmentReason of order item %s is required", a.AmazonOrderItemCode)
+	}
+	if len(a.ItemPriceAdjustments) == 0 {
+		return fmt.Errorf("order item %s has no price adjustments", a.AmazonOrderItemCode)
+	}
+	for _, c := range a.ItemPriceAdjustments {
+		if c.Amount.Currency == "" || c.Amount.Value == "" {
+			return fmt.Errorf("%s adjustment of order item %s has no amount or currency", c.Type, a.AmazonOrderItemCode)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package finances
+
+import "github.com/fond-of-vertigo/amazon-sp-api/internal/utils"
+
+// The enums of this file are lenient: values which are not listed here are kept when unmarshalling
+// instead of failing, as Amazon adds new types regularly. Use IsKnown to detect them.
+
+// ChargeType The type of charge.
+type ChargeType string
+
+const (
+	ChargeTypePrincipal                          ChargeType = "Principal"
+	ChargeTypeTax                                ChargeType = "Tax"
+	ChargeTypeMarketplaceFacilitatorTaxPrincipal ChargeType = "MarketplaceFacilitatorTax-Principal"
+	ChargeTypeMarketplaceFacilitatorTaxShipping  ChargeType = "MarketplaceFacilitatorTax-Shipping"
+	ChargeTypeMarketplaceFacilitatorTaxGiftwrap  ChargeType = "MarketplaceFacilitatorTax-Giftwrap"
+	ChargeTypeMarketplaceFacilitatorTaxOther     ChargeType = "MarketplaceFacilitatorTax-Other"
+	ChargeTypeDiscount                           ChargeType = "Discount"
+	ChargeTypeTaxDiscount                        ChargeType = "TaxDiscount"
+	ChargeTypeCODItemCharge                      ChargeType = "CODItemCharge"
+	ChargeTypeCODItemTaxCharge                   ChargeType = "CODItemTaxCharge"
+	ChargeTypeCODOrderCharge                     ChargeType = "CODOrderCharge"
+	ChargeTypeCODOrderTaxCharge                  ChargeType = "CODOrderTaxCharge"
+	ChargeTypeCODShippingCharge                  ChargeType = "CODShippingCharge"
+	ChargeTypeCODShippingTaxCharge               ChargeType = "CODShippingTaxCharge"
+	ChargeTypeShippingCharge                     ChargeType = "ShippingCharge"
+	ChargeTypeShippingTax                        ChargeType = "ShippingTax"
+	ChargeTypeGoodwill                           ChargeType = "Goodwill"
+	ChargeTypeGiftwrap                           ChargeType = "Giftwrap"
+	ChargeTypeGiftwrapTax                        ChargeType = "GiftwrapTax"
+	ChargeTypeRestockingFee                      ChargeType = "RestockingFee"
+	ChargeTypeReturnShipping                     ChargeType = "ReturnShipping"
+	ChargeTypePointsFee                          ChargeType = "PointsFee"
+	ChargeTypeGenericDeduction                   ChargeType = "GenericDeduction"
+	ChargeTypeFreeReplacementReturnShipping      ChargeType = "FreeReplacementReturnShipping"
+	ChargeTypePaymentMethodFee                   ChargeType = "PaymentMethodFee"
+	ChargeTypeExportCharge                       ChargeType = "ExportCharge"
+	ChargeTypeSAFETReimbursement                 ChargeType = "SAFE-TReimbursement"
+	ChargeTypeTCSCGST                            ChargeType = "TCS-CGST"
+	ChargeTypeTCSSGST                            ChargeType = "TCS-SGST"
+	ChargeTypeTCSIGST                            ChargeType = "TCS-IGST"
+	ChargeTypeTCSUTGST                           ChargeType = "TCS-UTGST"
+)
+
+var knownChargeTypes = utils.NewSet[ChargeType](
+	ChargeTypePrincipal, ChargeTypeTax,
+	ChargeTypeMarketplaceFacilitatorTaxPrincipal, ChargeTypeMarketplaceFacilitatorTaxShipping,
+	ChargeTypeMarketplaceFacilitatorTaxGiftwrap, ChargeTypeMarketplaceFacilitatorTaxOther,
+	ChargeTypeDiscount, ChargeTypeTaxDiscount,
+	ChargeTypeCODItemCharge, ChargeTypeCODItemTaxCharge, ChargeTypeCODOrderCharge, ChargeTypeCODOrderTaxCharge,
+	ChargeTypeCODShippingCharge, ChargeTypeCODShippingTaxCharge,
+	ChargeTypeShippingCharge, ChargeTypeShippingTax, ChargeTypeGoodwill, ChargeTypeGiftwrap, ChargeTypeGiftwrapTax,
+	ChargeTypeRestockingFee, ChargeTypeReturnShipping, ChargeTypePointsFee, ChargeTypeGenericDeduction,
+	ChargeTypeFreeReplacementReturnShipping, ChargeTypePaymentMethodFee, ChargeTypeExportCharge,
+	ChargeTypeSAFETReimbursement, ChargeTypeTCSCGST, ChargeTypeTCSSGST, ChargeTypeTCSIGST, ChargeTypeTCSUTGST,
+)
+
+func (t *ChargeType) UnmarshalJSON(b []byte) error {
+	value, _, err := utils.UnmarshalJSONEnumLenient[ChargeType](b, knownChargeTypes)
+	if err != nil {
+		return err
+	}
+	*t = value
+	return nil
+}
+
+// IsKnown reports whether the charge type is one of the ChargeType constants.
+func (t ChargeType) IsKnown() bool {
+	return knownChargeTypes.Has(t)
+}
+
+// FeeType The type of fee.
+type FeeType string
+
+const (
+	FeeTypeAmazonExclusivesFee         FeeType = "AmazonExclusivesFee"
+	FeeTypeBubblewrapFee               FeeType = "BubblewrapFee"
+	FeeTypeCODChargeback               FeeType = "CODChargeback"
+	FeeTypeCommission                  FeeType = "Commission"
+	FeeTypeDigitalServicesFee          FeeType = "DigitalServicesFee"
+	FeeTypeDigitalServicesFeeFBA       FeeType = "DigitalServicesFeeFBA"
+	FeeTypeFBADisposalFee              FeeType = "FBADisposalFee"
+	FeeTypeFBAInboundConvenienceFee    FeeType = "FBAInboundConvenienceFee"
+	FeeTypeFBAInboundDefectFee         FeeType = "FBAInboundDefectFee"
+	FeeTypeFBAInboundTransportationFee FeeType = "FBAInboundTransportationFee"
+	FeeTypeFBALongTermStorageFee       FeeType = "FBALongTermStorageFee"
+	FeeTypeFBAOverageFee               FeeType = "FBAOverageFee"
+	FeeTypeFBAPerOrderFulfillmentFee   FeeType = "FBAPerOrderFulfillmentFee"
+	FeeTypeFBAPerUnitFulfillmentFee    FeeType = "FBAPerUnitFulfillmentFee"
+	FeeTypeFBARemovalFee               FeeType = "FBARemovalFee"
+	FeeTypeFBAStorageFee               FeeType = "FBAStorageFee"
+	FeeTypeFBAWeightBasedFee           FeeType = "FBAWeightBasedFee"
+	FeeTypeFixedClosingFee             FeeType = "FixedClosingFee"
+	FeeTypeGiftwrapChargeback          FeeType = "GiftwrapChargeback"
+	FeeTypeGiftwrapCommission          FeeType = "GiftwrapCommission"
+	FeeTypeHighVolumeListingFee        FeeType = "HighVolumeListingFee"
+	FeeTypeLabelingFee                 FeeType = "LabelingFee"
+	FeeTypeManualProcessingFee         FeeType = "ManualProcessingFee"
+	FeeTypeOpaqueBaggingFee            FeeType = "OpaqueBaggingFee"
+	FeeTypePolybaggingFee              FeeType = "PolybaggingFee"
+	FeeTypeRefundCommission            FeeType = "RefundCommission"
+	FeeTypeRenewedProgramFee           FeeType = "RenewedProgramFee"
+	FeeTypeSalesTaxCollectionFee       FeeType = "SalesTaxCollectionFee"
+	FeeTypeShippingChargeback          FeeType = "ShippingChargeback"
+	FeeTypeShippingHB                  FeeType = "ShippingHB"
+	FeeTypeSubscriptionFee             FeeType = "SubscriptionFee"
+	FeeTypeTapingFee                   FeeType = "TapingFee"
+	FeeTypeTransportationFee           FeeType = "TransportationFee"
+	FeeTypeVariableClosingFee          FeeType = "VariableClosingFee"
+)
+
+var knownFeeTypes = utils.NewSet[FeeType](
+	FeeTypeAmazonExclusivesFee, FeeTypeBubblewrapFee, FeeTypeCODChargeback, FeeTypeCommission,
+	FeeTypeDigitalServicesFee, FeeTypeDigitalServicesFeeFBA, FeeTypeFBADisposalFee, FeeTypeFBAInboundConvenienceFee,
+	FeeTypeFBAInboundDefectFee, FeeTypeFBAInboundTransportationFee, FeeTypeFBALongTermStorageFee, FeeTypeFBAOverageFee,
+	FeeTypeFBAPerOrderFulfillmentFee, FeeTypeFBAPerUnitFulfillmentFee, FeeTypeFBARemovalFee, FeeTypeFBAStorageFee,
+	FeeTypeFBAWeightBasedFee, FeeTypeFixedClosingFee, FeeTypeGiftwrapChargeback, FeeTypeGiftwrapCommission,
+	FeeTypeHighVolumeListingFee, FeeTypeLabelingFee, FeeTypeManualProcessingFee, FeeTypeOpaqueBaggingFee,
+	FeeTypePolybaggingFee, FeeTypeRefundCommission, FeeTypeRenewedProgramFee, FeeTypeSalesTaxCollectionFee,
+	FeeTypeShippingChargeback, FeeTypeShippingHB, FeeTypeSubscriptionFee, FeeTypeTapingFee,
+	FeeTypeTransportationFee, FeeTypeVariableClosingFee,
+)
+
+func (t *FeeType) UnmarshalJSON(b []byte) error {
+	value, _, err := utils.UnmarshalJSONEnumLenient[FeeType](b, knownFeeTypes)
+	if err != nil {
+		return err
+	}
+	*t = value
+	return nil
+}
+
+// IsKnown reports whether the fee type is one of the FeeType constants.
+func (t FeeType) IsKnown() bool {
+	return knownFeeTypes.Has(t)
+}
+
+// AdjustmentType The type of adjustment.
+type AdjustmentType string
+
+const (
+	AdjustmentTypeFBAInventoryReimbursement        AdjustmentType = "FBAInventoryReimbursement"
+	AdjustmentTypeReserveEvent                     AdjustmentType = "ReserveEvent"
+	AdjustmentTypePostageBilling                   AdjustmentType = "PostageBilling"
+	AdjustmentTypePostageRefund                    AdjustmentType = "PostageRefund"
+	AdjustmentTypeLostOrDamagedReimbursement       AdjustmentType = "LostOrDamagedReimbursement"
+	AdjustmentTypeCanceledButPickedUpReimbursement AdjustmentType = "CanceledButPickedUpReimbursement"
+	AdjustmentTypeReimbursementClawback            AdjustmentType = "ReimbursementClawback"
+	AdjustmentTypeSellerRewards                    AdjustmentType = "SellerRewards"
+)
+
+var knownAdjustmentTypes = utils.NewSet[AdjustmentType](
+	AdjustmentTypeFBAInventoryReimbursement, AdjustmentTypeReserveEvent, AdjustmentTypePostageBilling,
+	AdjustmentTypePostageRefund, AdjustmentTypeLostOrDamagedReimbursement, AdjustmentTypeCanceledButPickedUpReimbursement,
+	AdjustmentTypeReimbursementClawback, AdjustmentTypeSellerRewards,
+)
+
+func (t *AdjustmentType) UnmarshalJSON(b []byte) error {
+	value, _, err := utils.UnmarshalJSONEnumLenient[AdjustmentType](b, knownAdjustmentTypes)
+	if err != nil {
+		return err
+	}
+	*t = value
+	return nil
+}
+
+// IsKnown reports whether the adjustment type is one of the AdjustmentType constants.
+func (t AdjustmentType) IsKnown() bool {
+	return knownAdjustmentTypes.Has(t)
+}
+
+// ProcessingStatus The processing status of a financial event group.
+type ProcessingStatus string
+
+const (
+	// ProcessingStatusOpen means the balance of the financial event group is not settled yet.
+	ProcessingStatusOpen ProcessingStatus = "Open"
+	// ProcessingStatusClosed means the balance of the financial event group is settled.
+	ProcessingStatusClosed ProcessingStatus = "Closed"
+)
+
+var knownProcessingStatuses = utils.NewSet[ProcessingStatus](ProcessingStatusOpen, ProcessingStatusClosed)
+
+func (s *ProcessingStatus) UnmarshalJSON(b []byte) error {
+	value, _, err := utils.UnmarshalJSONEnumLenient[ProcessingStatus](b, knownProcessingStatuses)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
+
+// IsKnown reports whether the processing status is one of the ProcessingStatus constants.
+func (s ProcessingStatus) IsKnown() bool {
+	return knownProcessingStatuses.Has(s)
+}
@@ -0,0 +1,21 @@
+package finances
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeType_UnmarshalJSON(t *testing.T) {
+	var fees []FeeComponent
+	err := json.Unmarshal([]byte(`[{"FeeType": "Commission"}, {"FeeType": "BrandNewFee"}]`), &fees)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, FeeTypeCommission, *fees[0].FeeType)
+	assert.True(t, fees[0].FeeType.IsKnown())
+	assert.Equal(t, FeeType("BrandNewFee"), *fees[1].FeeType)
+	assert.False(t, fees[1].FeeType.IsKnown())
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
 	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
 )
 
@@ -60,10 +61,15 @@ func (a *API) ListFinancialEventsByOrderID(orderID string, filter *ListFinancial
 }
 
 // ListFinancialEvents returns financial events for the specified data range.
+// The range must not exceed 180 days, see IterateFinancialEvents for longer ranges.
 func (a *API) ListFinancialEvents(filter *ListFinancialEventsFilter) (*apis.CallResponse[ListFinancialEventsResponse], error) {
 	if filter.MaxResultsPerPage != nil && (*filter.MaxResultsPerPage < 1 || *filter.MaxResultsPerPage > 100) {
 		return nil, errors.New("maxResultsPerPage must be between 1 and 100")
 	}
+	if filter.PostedAfter != nil && filter.PostedBefore != nil &&
+		filter.PostedBefore.Sub(filter.PostedAfter.Time) > constants.MaxFinancialEventsRange {
+		return nil, errors.New("postedAfter and postedBefore must not be more than 180 days apart, use IterateFinancialEvents for longer ranges")
+	}
 
 	return apis.NewCall[ListFinancialEventsResponse](http.MethodGet, pathPrefix+"/financialEvents").
 		WithQueryParams(filter.GetQuery()).
@@ -0,0 +1,201 @@
+package finances
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// FinancialEventsIterator pages through the financial events of a time range of any length.
+// The range is split into windows of at most constants.MaxFinancialEventsRange, which are
+// queried in ascending order. Only the windows are ordered, the events of a page keep the order
+// of the API; All sorts the merged events by posted date.
+//
+//	it, err := api.IterateFinancialEvents(from, time.Time{})
+//	for it.Next() {
+//		process(it.Events())
+//	}
+//	err = it.Err()
+type FinancialEventsIterator struct {
+	listFinancialEvents func(filter *ListFinancialEventsFilter) (*apis.CallResponse[ListFinancialEventsResponse], error)
+	windows             []timeWindow
+	window              int
+	nextToken           *string
+	events              *FinancialEvents
+	err                 error
+}
+
+type timeWindow struct {
+	from, to time.Time
+}
+
+// IterateFinancialEvents returns an iterator over all financial events posted in [postedAfter, postedBefore).
+// A zero postedBefore, or one later than two minutes ago, is set to two minutes ago.
+func (a *API) IterateFinancialEvents(postedAfter time.Time, postedBefore time.Time) (*FinancialEventsIterator, error) {
+	windows, err := splitPostedRange(postedAfter, postedBefore, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &FinancialEventsIterator{
+		listFinancialEvents: a.ListFinancialEvents,
+		windows:             windows,
+	}, nil
+}
+
+// Next fetches the next page of financial events. It returns false when all windows are
+// exhausted or an error occurred, which is returned by Err then.
+func (it *FinancialEventsIterator) Next() bool {
+	for it.err == nil && it.window < len(it.windows) {
+		w := it.windows[it.window]
+		maxResults := 100
+		filter := &ListFinancialEventsFilter{MaxResultsPerPage: &maxResults, NextToken: it.nextToken}
+		if it.nextToken == nil {
+			filter.PostedAfter = &apis.JsonTimeISO8601{Time: w.from}
+			filter.PostedBefore = &apis.JsonTimeISO8601{Time: w.to}
+		}
+
+		resp, err := it.listFinancialEvents(filter)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if resp.ResponseBody == nil || resp.ResponseBody.Payload == nil {
+			it.err = errors.New("listFinancialEvents returned an empty response")
+			return false
+		}
+
+		payload := resp.ResponseBody.Payload
+		it.nextToken = payload.NextToken
+		if it.nextToken != nil && *it.nextToken == "" {
+			it.nextToken = nil
+		}
+		if it.nextToken == nil {
+			it.window++
+		}
+		if payload.FinancialEvents != nil {
+			it.events = payload.FinancialEvents
+			return true
+		}
+	}
+	return false
+}
+
+// Events returns the financial events of the current page.
+func (it *FinancialEventsIterator) Events() *FinancialEvents {
+	return it.events
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *FinancialEventsIterator) Err() error {
+	return it.err
+}
+
+// All fetches all remaining pages and merges them into a single FinancialEvents,
+// whose event lists are sorted by posted date.
+func (it *FinancialEventsIterator) All() (*FinancialEvents, error) {
+	merged := &FinancialEvents{}
+	for it.Next() {
+		merged.Append(it.Events())
+	}
+	merged.SortByPostedDate()
+	return merged, it.Err()
+}
+
+// Append appends all event lists of other to the event lists of e.
+func (e *FinancialEvents) Append(other *FinancialEvents) {
+	e.ShipmentEventList = append(e.ShipmentEventList, other.ShipmentEventList...)
+	e.RefundEventList = append(e.RefundEventList, other.RefundEventList...)
+	e.GuaranteeClaimEventList = append(e.GuaranteeClaimEventList, other.GuaranteeClaimEventList...)
+	e.ChargebackEventList = append(e.ChargebackEventList, other.ChargebackEventList...)
+	e.PayWithAmazonEventList = append(e.PayWithAmazonEventList, other.PayWithAmazonEventList...)
+	e.ServiceProviderCreditEventList = append(e.ServiceProviderCreditEventList, other.ServiceProviderCreditEventList...)
+	e.RetrochargeEventList = append(e.RetrochargeEventList, other.RetrochargeEventList...)
+	e.RentalTransactionEventList = append(e.RentalTransactionEventList, other.RentalTransactionEventList...)
+	e.ProductAdsPaymentEventList = append(e.ProductAdsPaymentEventList, other.ProductAdsPaymentEventList...)
+	e.ServiceFeeEventList = append(e.ServiceFeeEventList, other.ServiceFeeEventList...)
+	e.SellerDealPaymentEventList = append(e.SellerDealPaymentEventList, other.SellerDealPaymentEventList...)
+	e.DebtRecoveryEventList = append(e.DebtRecoveryEventList, other.DebtRecoveryEventList...)
+	e.LoanServicingEventList = append(e.LoanServicingEventList, other.LoanServicingEventList...)
+	e.AdjustmentEventList = append(e.AdjustmentEventList, other.AdjustmentEventList...)
+	e.SAFETReimbursementEventList = append(e.SAFETReimbursementEventList, other.SAFETReimbursementEventList...)
+	e.SellerReviewEnrollmentPaymentEventList = append(e.SellerReviewEnrollmentPaymentEventList, other.SellerReviewEnrollmentPaymentEventList...)
+	e.FBALiquidationEventList = append(e.FBALiquidationEventList, other.FBALiquidationEventList...)
+	e.CouponPaymentEventList = append(e.CouponPaymentEventList, other.CouponPaymentEventList...)
+	e.ImagingServicesFeeEventList = append(e.ImagingServicesFeeEventList, other.ImagingServicesFeeEventList...)
+	e.NetworkComminglingTransactionEventList = append(e.NetworkComminglingTransactionEventList, other.NetworkComminglingTransactionEventList...)
+	e.AffordabilityExpenseEventList = append(e.AffordabilityExpenseEventList, other.AffordabilityExpenseEventList...)
+	e.AffordabilityExpenseReversalEventList = append(e.AffordabilityExpenseReversalEventList, other.AffordabilityExpenseReversalEventList...)
+	e.TrialShipmentEventList = append(e.TrialShipmentEventList, other.TrialShipmentEventList...)
+	e.ShipmentSettleEventList = append(e.ShipmentSettleEventList, other.ShipmentSettleEventList...)
+	e.TaxWithholdingEventList = append(e.TaxWithholdingEventList, other.TaxWithholdingEventList...)
+	e.RemovalShipmentEventList = append(e.RemovalShipmentEventList, other.RemovalShipmentEventList...)
+	e.RemovalShipmentAdjustmentEventList = append(e.RemovalShipmentAdjustmentEventList, other.RemovalShipmentAdjustmentEventList...)
+}
+
+// SortByPostedDate sorts every event list by posted date. The order of events with the same
+// date is kept and events without date are moved to the end. ServiceProviderCreditEventList,
+// ServiceFeeEventList, DebtRecoveryEventList and LoanServicingEventList have no posted date
+// and are not sorted.
+func (e *FinancialEvents) SortByPostedDate() {
+	sortByPostedDate(e.ShipmentEventList, func(ev *ShipmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.RefundEventList, func(ev *ShipmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.GuaranteeClaimEventList, func(ev *ShipmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.ChargebackEventList, func(ev *ShipmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.PayWithAmazonEventList, func(ev *PayWithAmazonEvent) *time.Time { return ev.TransactionPostedDate })
+	sortByPostedDate(e.RetrochargeEventList, func(ev *RetrochargeEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.RentalTransactionEventList, func(ev *RentalTransactionEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.ProductAdsPaymentEventList, func(ev *ProductAdsPaymentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.SellerDealPaymentEventList, func(ev *SellerDealPaymentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.AdjustmentEventList, func(ev *AdjustmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.SAFETReimbursementEventList, func(ev *SAFETReimbursementEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.SellerReviewEnrollmentPaymentEventList, func(ev *SellerReviewEnrollmentPaymentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.FBALiquidationEventList, func(ev *FBALiquidationEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.CouponPaymentEventList, func(ev *CouponPaymentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.ImagingServicesFeeEventList, func(ev *ImagingServicesFeeEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.NetworkComminglingTransactionEventList, func(ev *NetworkComminglingTransactionEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.AffordabilityExpenseEventList, func(ev *AffordabilityExpenseEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.AffordabilityExpenseReversalEventList, func(ev *AffordabilityExpenseEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.TrialShipmentEventList, func(ev *TrialShipmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.ShipmentSettleEventList, func(ev *ShipmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.TaxWithholdingEventList, func(ev *TaxWithholdingEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.RemovalShipmentEventList, func(ev *RemovalShipmentEvent) *time.Time { return ev.PostedDate })
+	sortByPostedDate(e.RemovalShipmentAdjustmentEventList, func(ev *RemovalShipmentAdjustmentEvent) *time.Time { return ev.PostedDate })
+}
+
+func sortByPostedDate[T any](events []T, postedDate func(*T) *time.Time) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := postedDate(&events[i]), postedDate(&events[j])
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+}
+
+// splitPostedRange validates and normalizes the range and splits it into windows
+// accepted by the listFinancialEvents operation.
+func splitPostedRange(postedAfter time.Time, postedBefore time.Time, now time.Time) ([]timeWindow, error) {
+	if postedAfter.IsZero() {
+		return nil, errors.New("postedAfter is required")
+	}
+	latest := now.Add(-constants.MinBeforeFilterAge)
+	if postedBefore.IsZero() || postedBefore.After(latest) {
+		postedBefore = latest
+	}
+	if !postedBefore.After(postedAfter) {
+		return nil, errors.New("postedAfter must be before postedBefore and at least two minutes in the past")
+	}
+
+	var windows []timeWindow
+	for from := postedAfter; from.Before(postedBefore); from = from.Add(constants.MaxFinancialEventsRange) {
+		to := from.Add(constants.MaxFinancialEventsRange)
+		if to.After(postedBefore) {
+			to = postedBefore
+		}
+		windows = append(windows, timeWindow{from: from, to: to})
+	}
+	return windows, nil
+}
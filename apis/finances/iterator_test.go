@@ -0,0 +1,141 @@
+package finances
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_splitPostedRange(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tests := []struct {
+		name         string
+		postedAfter  time.Time
+		postedBefore time.Time
+		wantWindows  int
+		wantLastTo   time.Time
+		wantErr      bool
+	}{
+		{name: "Short range", postedAfter: now.Add(-10 * day), postedBefore: now.Add(-day), wantWindows: 1, wantLastTo: now.Add(-day)},
+		{name: "Exactly 180 days", postedAfter: now.Add(-181 * day), postedBefore: now.Add(-day), wantWindows: 1, wantLastTo: now.Add(-day)},
+		{name: "Long range", postedAfter: now.Add(-400 * day), postedBefore: now.Add(-day), wantWindows: 3, wantLastTo: now.Add(-day)},
+		{name: "Zero postedBefore", postedAfter: now.Add(-day), wantWindows: 1, wantLastTo: now.Add(-2 * time.Minute)},
+		{name: "postedBefore in the future", postedAfter: now.Add(-day), postedBefore: now.Add(time.Hour), wantWindows: 1, wantLastTo: now.Add(-2 * time.Minute)},
+		{name: "Zero postedAfter", postedBefore: now.Add(-day), wantErr: true},
+		{name: "postedAfter within the last two minutes", postedAfter: now.Add(-time.Minute), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitPostedRange(tt.postedAfter, tt.postedBefore, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitPostedRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.Len(t, got, tt.wantWindows)
+			assert.Equal(t, tt.postedAfter, got[0].from)
+			assert.Equal(t, tt.wantLastTo, got[len(got)-1].to)
+			for i, w := range got {
+				assert.LessOrEqual(t, w.to.Sub(w.from), 180*day)
+				if i > 0 {
+					assert.Equal(t, got[i-1].to, w.from)
+				}
+			}
+		})
+	}
+}
+
+func TestFinancialEventsIterator(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	windows, err := splitPostedRange(now.Add(-200*24*time.Hour), now, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var filters []*ListFinancialEventsFilter
+	nextToken := "page-2"
+	it := &FinancialEventsIterator{
+		windows: windows,
+		listFinancialEvents: func(filter *ListFinancialEventsFilter) (*apis.CallResponse[ListFinancialEventsResponse], error) {
+			filters = append(filters, filter)
+			feeReason := fmt.Sprintf("call %d", len(filters))
+			payload := &ListFinancialEventsPayload{FinancialEvents: &FinancialEvents{
+				ServiceFeeEventList: []ServiceFeeEvent{{FeeReason: &feeReason}},
+			}}
+			if len(filters) == 1 {
+				payload.NextToken = &nextToken
+			}
+			return &apis.CallResponse[ListFinancialEventsResponse]{
+				ResponseBody: &ListFinancialEventsResponse{Payload: payload},
+			}, nil
+		},
+	}
+
+	events, err := it.All()
+
+	assert.NoError(t, err)
+	assert.Len(t, filters, 3)
+	assert.Equal(t, windows[0].from, filters[0].PostedAfter.Time)
+	assert.Equal(t, "page-2", *filters[1].NextToken)
+	assert.Nil(t, filters[1].PostedAfter)
+	assert.Equal(t, windows[1].from, filters[2].PostedAfter.Time)
+	assert.Equal(t, windows[1].to, filters[2].PostedBefore.Time)
+	assert.Len(t, events.ServiceFeeEventList, 3)
+	assert.Equal(t, "call 3", *events.ServiceFeeEventList[2].FeeReason)
+}
+
+func TestFinancialEventsIterator_AllSortsByPostedDate(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	windows, err := splitPostedRange(now.Add(-24*time.Hour), now, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shipment := func(orderID string, postedDate *time.Time) ShipmentEvent {
+		return ShipmentEvent{AmazonOrderId: &orderID, PostedDate: postedDate}
+	}
+	at := func(hour int) *time.Time {
+		date := now.Add(time.Duration(hour-24) * time.Hour)
+		return &date
+	}
+
+	pages := []*FinancialEvents{
+		{
+			ShipmentEventList:   []ShipmentEvent{shipment("3", at(3)), shipment("undated", nil), shipment("1", at(1))},
+			AdjustmentEventList: []AdjustmentEvent{{PostedDate: at(5)}, {PostedDate: at(2)}},
+		},
+		{
+			ShipmentEventList: []ShipmentEvent{shipment("4", at(4)), shipment("2", at(2)), shipment("2b", at(2))},
+		},
+	}
+	nextToken := "page-2"
+	calls := 0
+	it := &FinancialEventsIterator{
+		windows: windows,
+		listFinancialEvents: func(filter *ListFinancialEventsFilter) (*apis.CallResponse[ListFinancialEventsResponse], error) {
+			payload := &ListFinancialEventsPayload{FinancialEvents: pages[calls]}
+			calls++
+			if calls == 1 {
+				payload.NextToken = &nextToken
+			}
+			return &apis.CallResponse[ListFinancialEventsResponse]{
+				ResponseBody: &ListFinancialEventsResponse{Payload: payload},
+			}, nil
+		},
+	}
+
+	events, err := it.All()
+
+	assert.NoError(t, err)
+	var orderIDs []string
+	for _, ev := range events.ShipmentEventList {
+		orderIDs = append(orderIDs, *ev.AmazonOrderId)
+	}
+	assert.Equal(t, []string{"1", "2", "2b", "3", "4", "undated"}, orderIDs)
+	assert.Equal(t, at(2), events.AdjustmentEventList[0].PostedDate)
+	assert.Equal(t, at(5), events.AdjustmentEventList[1].PostedDate)
+}
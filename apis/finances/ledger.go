@@ -0,0 +1,403 @@
+package finances
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+)
+
+// EventType is the kind of financial event a LedgerEntry originates from.
+// It is the name of the event list in FinancialEvents without the "EventList" suffix.
+type EventType string
+
+const (
+	EventTypeShipment                      EventType = "Shipment"
+	EventTypeRefund                        EventType = "Refund"
+	EventTypeGuaranteeClaim                EventType = "GuaranteeClaim"
+	EventTypeChargeback                    EventType = "Chargeback"
+	EventTypePayWithAmazon                 EventType = "PayWithAmazon"
+	EventTypeServiceProviderCredit         EventType = "ServiceProviderCredit"
+	EventTypeRetrocharge                   EventType = "Retrocharge"
+	EventTypeRentalTransaction             EventType = "RentalTransaction"
+	EventTypeProductAdsPayment             EventType = "ProductAdsPayment"
+	EventTypeServiceFee                    EventType = "ServiceFee"
+	EventTypeSellerDealPayment             EventType = "SellerDealPayment"
+	EventTypeDebtRecovery                  EventType = "DebtRecovery"
+	EventTypeLoanServicing                 EventType = "LoanServicing"
+	EventTypeAdjustment                    EventType = "Adjustment"
+	EventTypeSAFETReimbursement            EventType = "SAFETReimbursement"
+	EventTypeSellerReviewEnrollmentPayment EventType = "SellerReviewEnrollmentPayment"
+	EventTypeFBALiquidation                EventType = "FBALiquidation"
+	EventTypeCouponPayment                 EventType = "CouponPayment"
+	EventTypeImagingServicesFee            EventType = "ImagingServicesFee"
+	EventTypeNetworkComminglingTransaction EventType = "NetworkComminglingTransaction"
+	EventTypeAffordabilityExpense          EventType = "AffordabilityExpense"
+	EventTypeAffordabilityExpenseReversal  EventType = "AffordabilityExpenseReversal"
+	EventTypeTrialShipment                 EventType = "TrialShipment"
+	EventTypeShipmentSettle                EventType = "ShipmentSettle"
+	EventTypeTaxWithholding                EventType = "TaxWithholding"
+	EventTypeRemovalShipment               EventType = "RemovalShipment"
+	EventTypeRemovalShipmentAdjustment     EventType = "RemovalShipmentAdjustment"
+)
+
+// AmountKind classifies the amount of a LedgerEntry.
+type AmountKind string
+
+const (
+	AmountKindCharge        AmountKind = "Charge"
+	AmountKindFee           AmountKind = "Fee"
+	AmountKindPromotion     AmountKind = "Promotion"
+	AmountKindTaxWithheld   AmountKind = "TaxWithheld"
+	AmountKindDirectPayment AmountKind = "DirectPayment"
+	// AmountKindOther is used for amounts without charge or fee component,
+	// e.g. the LoanAmount of a LoanServicingEvent. AmountType is the name of the field then.
+	AmountKindOther AmountKind = "Other"
+)
+
+// LedgerEntry is a single money movement of a financial event.
+type LedgerEntry struct {
+	// PostedDate is the date the event was posted. It is zero for events without date,
+	// e.g. DebtRecoveryEvent, LoanServicingEvent and ServiceFeeEvent.
+	PostedDate   time.Time
+	EventType    EventType
+	OrderID      string
+	OrderItemID  string
+	SKU          string
+	Marketplace  string
+	AmountKind   AmountKind
+	AmountType   string
+	Amount       apis.Amount
+	CurrencyCode string
+	// Source is the path of the amount within the FinancialEvents,
+	// e.g. "ShipmentEventList[3].ShipmentItemList[0].ItemFeeList[1]".
+	Source string
+	// SourceID is the identifier of the source event if it has one, e.g. the InvoiceId of a ProductAdsPaymentEvent.
+	SourceID string
+}
+
+// Ledger flattens all financial events into one LedgerEntry per money movement, in the order of
+// the event lists in FinancialEvents. Totals which are the sum of other amounts of the same event,
+// e.g. TotalAmount of a CouponPaymentEvent, are skipped so the entries can be summed up.
+// Amounts which are not set are skipped as well.
+func (e *FinancialEvents) Ledger() []LedgerEntry {
+	l := &ledger{}
+	shipmentLists := []struct {
+		eventType EventType
+		list      string
+		events    []ShipmentEvent
+	}{
+		{EventTypeShipment, "ShipmentEventList", e.ShipmentEventList},
+		{EventTypeRefund, "RefundEventList", e.RefundEventList},
+		{EventTypeGuaranteeClaim, "GuaranteeClaimEventList", e.GuaranteeClaimEventList},
+		{EventTypeChargeback, "ChargebackEventList", e.ChargebackEventList},
+	}
+	for _, s := range shipmentLists {
+		l.addShipmentEvents(s.eventType, s.list, s.events)
+	}
+
+	for i, ev := range e.PayWithAmazonEventList {
+		entry := l.entry(EventTypePayWithAmazon, "PayWithAmazonEventList", i, ev.TransactionPostedDate)
+		entry.OrderID = str(ev.SellerOrderId)
+		l.addCharge(entry, ".Charge", ev.Charge)
+		l.addFees(entry, ".FeeList", ev.FeeList)
+	}
+	for i, ev := range e.ServiceProviderCreditEventList {
+		entry := l.entry(EventTypeServiceProviderCredit, "ServiceProviderCreditEventList", i, ev.TransactionCreationDate)
+		entry.OrderID = str(ev.SellerOrderId)
+		entry.Marketplace = str(ev.MarketplaceId)
+		l.addOther(entry, ".TransactionAmount", ev.TransactionAmount)
+	}
+	for i, ev := range e.RetrochargeEventList {
+		entry := l.entry(EventTypeRetrocharge, "RetrochargeEventList", i, ev.PostedDate)
+		entry.OrderID = str(ev.AmazonOrderId)
+		entry.Marketplace = str(ev.MarketplaceName)
+		l.addOther(entry, ".BaseTax", ev.BaseTax)
+		l.addOther(entry, ".ShippingTax", ev.ShippingTax)
+		l.addTaxesWithheld(entry, ".RetrochargeTaxWithheldList", ev.RetrochargeTaxWithheldList)
+	}
+	for i, ev := range e.RentalTransactionEventList {
+		entry := l.entry(EventTypeRentalTransaction, "RentalTransactionEventList", i, ev.PostedDate)
+		entry.OrderID = str(ev.AmazonOrderId)
+		entry.Marketplace = str(ev.MarketplaceName)
+		l.addCharges(entry, ".RentalChargeList", ev.RentalChargeList)
+		l.addFees(entry, ".RentalFeeList", ev.RentalFeeList)
+		l.addTaxesWithheld(entry, ".RentalTaxWithheldList", ev.RentalTaxWithheldList)
+		l.addOther(entry, ".RentalReimbursement", ev.RentalReimbursement)
+	}
+	for i, ev := range e.ProductAdsPaymentEventList {
+		entry := l.entry(EventTypeProductAdsPayment, "ProductAdsPaymentEventList", i, ev.PostedDate)
+		entry.SourceID = str(ev.InvoiceId)
+		l.addOther(entry, ".baseValue", ev.BaseValue)
+		l.addOther(entry, ".taxValue", ev.TaxValue)
+	}
+	for i, ev := range e.ServiceFeeEventList {
+		entry := l.entry(EventTypeServiceFee, "ServiceFeeEventList", i, nil)
+		entry.OrderID = str(ev.AmazonOrderId)
+		entry.SKU = str(ev.SellerSKU)
+		l.addFees(entry, ".FeeList", ev.FeeList)
+	}
+	for i, ev := range e.SellerDealPaymentEventList {
+		entry := l.entry(EventTypeSellerDealPayment, "SellerDealPaymentEventList", i, ev.PostedDate)
+		entry.SourceID = str(ev.DealId)
+		l.add(entry, ".feeAmount", AmountKindFee, str(ev.FeeType), ev.FeeAmount)
+		l.addOther(entry, ".taxAmount", ev.TaxAmount)
+	}
+	for i, ev := range e.DebtRecoveryEventList {
+		entry := l.entry(EventTypeDebtRecovery, "DebtRecoveryEventList", i, nil)
+		l.addOther(entry, ".RecoveryAmount", ev.RecoveryAmount)
+		l.addOther(entry, ".OverPaymentCredit", ev.OverPaymentCredit)
+	}
+	for i, ev := range e.LoanServicingEventList {
+		entry := l.entry(EventTypeLoanServicing, "LoanServicingEventList", i, nil)
+		l.addOther(entry, ".LoanAmount", ev.LoanAmount)
+	}
+	for i, ev := range e.AdjustmentEventList {
+		entry := l.entry(EventTypeAdjustment, "AdjustmentEventList", i, ev.PostedDate)
+		if len(ev.AdjustmentItemList) == 0 {
+			l.add(entry, ".AdjustmentAmount", AmountKindOther, str(ev.AdjustmentType), ev.AdjustmentAmount)
+			continue
+		}
+		for j, item := range ev.AdjustmentItemList {
+			itemEntry := entry
+			itemEntry.SKU = str(item.SellerSKU)
+			l.add(itemEntry, fmt.Sprintf(".AdjustmentItemList[%d].TotalAmount", j), AmountKindOther, str(ev.AdjustmentType), item.TotalAmount)
+		}
+	}
+	for i, ev := range e.SAFETReimbursementEventList {
+		entry := l.entry(EventTypeSAFETReimbursement, "SAFETReimbursementEventList", i, ev.PostedDate)
+		entry.SourceID = str(ev.SAFETClaimId)
+		if len(ev.SAFETReimbursementItemList) == 0 {
+			l.addOther(entry, ".ReimbursedAmount", ev.ReimbursedAmount)
+			continue
+		}
+		for j, item := range ev.SAFETReimbursementItemList {
+			l.addCharges(entry, fmt.Sprintf(".SAFETReimbursementItemList[%d].itemChargeList", j), item.ItemChargeList)
+		}
+	}
+	for i, ev := range e.SellerReviewEnrollmentPaymentEventList {
+		entry := l.entry(EventTypeSellerReviewEnrollmentPayment, "SellerReviewEnrollmentPaymentEventList", i, ev.PostedDate)
+		entry.SourceID = str(ev.EnrollmentId)
+		l.addFee(entry, ".FeeComponent", ev.FeeComponent)
+		l.addCharge(entry, ".ChargeComponent", ev.ChargeComponent)
+	}
+	for i, ev := range e.FBALiquidationEventList {
+		entry := l.entry(EventTypeFBALiquidation, "FBALiquidationEventList", i, ev.PostedDate)
+		entry.OrderID = str(ev.OriginalRemovalOrderId)
+		l.addOther(entry, ".LiquidationProceedsAmount", ev.LiquidationProceedsAmount)
+		l.add(entry, ".LiquidationFeeAmount", AmountKindFee, "LiquidationFeeAmount", ev.LiquidationFeeAmount)
+	}
+	for i, ev := range e.CouponPaymentEventList {
+		entry := l.entry(EventTypeCouponPayment, "CouponPaymentEventList", i, ev.PostedDate)
+		entry.SourceID = str(ev.CouponId)
+		if ev.FeeComponent == nil && ev.ChargeComponent == nil {
+			l.addOther(entry, ".TotalAmount", ev.TotalAmount)
+			continue
+		}
+		l.addFee(entry, ".FeeComponent", ev.FeeComponent)
+		l.addCharge(entry, ".ChargeComponent", ev.ChargeComponent)
+	}
+	for i, ev := range e.ImagingServicesFeeEventList {
+		entry := l.entry(EventTypeImagingServicesFee, "ImagingServicesFeeEventList", i, ev.PostedDate)
+		entry.SourceID = str(ev.ImagingRequestBillingItemID)
+		l.addFees(entry, ".FeeList", ev.FeeList)
+	}
+	for i, ev := range e.NetworkComminglingTransactionEventList {
+		entry := l.entry(EventTypeNetworkComminglingTransaction, "NetworkComminglingTransactionEventList", i, ev.PostedDate)
+		entry.Marketplace = str(ev.MarketplaceId)
+		entry.SourceID = str(ev.NetCoTransactionID)
+		l.addOther(entry, ".TaxExclusiveAmount", ev.TaxExclusiveAmount)
+		l.addOther(entry, ".TaxAmount", ev.TaxAmount)
+	}
+	affordabilityLists := []struct {
+		eventType EventType
+		list      string
+		events    []AffordabilityExpenseEvent
+	}{
+		{EventTypeAffordabilityExpense, "AffordabilityExpenseEventList", e.AffordabilityExpenseEventList},
+		{EventTypeAffordabilityExpenseReversal, "AffordabilityExpenseReversalEventList", e.AffordabilityExpenseReversalEventList},
+	}
+	for _, a := range affordabilityLists {
+		for i, ev := range a.events {
+			entry := l.entry(a.eventType, a.list, i, ev.PostedDate)
+			entry.OrderID = str(ev.AmazonOrderId)
+			entry.Marketplace = str(ev.MarketplaceId)
+			l.addOther(entry, ".BaseExpense", ev.BaseExpense)
+			l.addOther(entry, ".TaxTypeCGST", &ev.TaxTypeCGST)
+			l.addOther(entry, ".TaxTypeSGST", &ev.TaxTypeSGST)
+			l.addOther(entry, ".TaxTypeIGST", &ev.TaxTypeIGST)
+		}
+	}
+	for i, ev := range e.TrialShipmentEventList {
+		entry := l.entry(EventTypeTrialShipment, "TrialShipmentEventList", i, ev.PostedDate)
+		entry.OrderID = str(ev.AmazonOrderId)
+		entry.SKU = str(ev.SKU)
+		entry.SourceID = str(ev.FinancialEventGroupId)
+		l.addFees(entry, ".FeeList", ev.FeeList)
+	}
+	l.addShipmentEvents(EventTypeShipmentSettle, "ShipmentSettleEventList", e.ShipmentSettleEventList)
+	for i, ev := range e.TaxWithholdingEventList {
+		entry := l.entry(EventTypeTaxWithholding, "TaxWithholdingEventList", i, ev.PostedDate)
+		l.add(entry, ".WithheldAmount", AmountKindTaxWithheld, "WithheldAmount", ev.WithheldAmount)
+	}
+	for i, ev := range e.RemovalShipmentEventList {
+		entry := l.entry(EventTypeRemovalShipment, "RemovalShipmentEventList", i, ev.PostedDate)
+		entry.OrderID = str(ev.OrderId)
+		for j, item := range ev.RemovalShipmentItemList {
+			itemEntry := entry
+			itemEntry.SKU = str(item.FulfillmentNetworkSKU)
+			itemEntry.OrderItemID = str(item.RemovalShipmentItemId)
+			prefix := fmt.Sprintf(".RemovalShipmentItemList[%d]", j)
+			l.addOther(itemEntry, prefix+".Revenue", item.Revenue)
+			l.add(itemEntry, prefix+".FeeAmount", AmountKindFee, "FeeAmount", item.FeeAmount)
+			l.addOther(itemEntry, prefix+".TaxAmount", item.TaxAmount)
+			l.add(itemEntry, prefix+".TaxWithheld", AmountKindTaxWithheld, "TaxWithheld", item.TaxWithheld)
+		}
+	}
+	for i, ev := range e.RemovalShipmentAdjustmentEventList {
+		entry := l.entry(EventTypeRemovalShipmentAdjustment, "RemovalShipmentAdjustmentEventList", i, ev.PostedDate)
+		entry.OrderID = str(ev.OrderId)
+		entry.SourceID = str(ev.AdjustmentEventId)
+		for j, item := range ev.RemovalShipmentItemAdjustmentList {
+			itemEntry := entry
+			itemEntry.SKU = str(item.FulfillmentNetworkSKU)
+			itemEntry.OrderItemID = str(item.RemovalShipmentItemId)
+			prefix := fmt.Sprintf(".RemovalShipmentItemAdjustmentList[%d]", j)
+			l.addOther(itemEntry, prefix+".RevenueAdjustment", item.RevenueAdjustment)
+			l.addOther(itemEntry, prefix+".TaxAmountAdjustment", item.TaxAmountAdjustment)
+			l.add(itemEntry, prefix+".TaxWithheldAdjustment", AmountKindTaxWithheld, "TaxWithheldAdjustment", item.TaxWithheldAdjustment)
+		}
+	}
+	return l.entries
+}
+
+type ledger struct {
+	entries []LedgerEntry
+}
+
+// entry returns the template for all entries of an event. Its Source is the path of the event.
+func (l *ledger) entry(eventType EventType, list string, index int, postedDate *time.Time) LedgerEntry {
+	entry := LedgerEntry{
+		EventType: eventType,
+		Source:    fmt.Sprintf("%s[%d]", list, index),
+	}
+	if postedDate != nil {
+		entry.PostedDate = *postedDate
+	}
+	return entry
+}
+
+func (l *ledger) addShipmentEvents(eventType EventType, list string, events []ShipmentEvent) {
+	for i, ev := range events {
+		entry := l.entry(eventType, list, i, ev.PostedDate)
+		entry.OrderID = str(ev.AmazonOrderId)
+		entry.Marketplace = str(ev.MarketplaceName)
+		l.addCharges(entry, ".OrderChargeList", ev.OrderChargeList)
+		l.addCharges(entry, ".OrderChargeAdjustmentList", ev.OrderChargeAdjustmentList)
+		l.addFees(entry, ".ShipmentFeeList", ev.ShipmentFeeList)
+		l.addFees(entry, ".ShipmentFeeAdjustmentList", ev.ShipmentFeeAdjustmentList)
+		l.addFees(entry, ".OrderFeeList", ev.OrderFeeList)
+		l.addFees(entry, ".OrderFeeAdjustmentList", ev.OrderFeeAdjustmentList)
+		for j, p := range ev.DirectPaymentList {
+			l.add(entry, fmt.Sprintf(".DirectPaymentList[%d]", j), AmountKindDirectPayment, str(p.DirectPaymentType), p.DirectPaymentAmount)
+		}
+		l.addShipmentItems(entry, ".ShipmentItemList", ev.ShipmentItemList)
+		l.addShipmentItems(entry, ".ShipmentItemAdjustmentList", ev.ShipmentItemAdjustmentList)
+	}
+}
+
+func (l *ledger) addShipmentItems(entry LedgerEntry, path string, items []ShipmentItem) {
+	for i, item := range items {
+		itemEntry := entry
+		itemEntry.SKU = str(item.SellerSKU)
+		itemEntry.OrderItemID = str(item.OrderItemId)
+		if item.OrderAdjustmentItemId != nil {
+			itemEntry.SourceID = *item.OrderAdjustmentItemId
+		}
+		prefix := fmt.Sprintf("%s[%d]", path, i)
+		l.addCharges(itemEntry, prefix+".ItemChargeList", item.ItemChargeList)
+		l.addCharges(itemEntry, prefix+".ItemChargeAdjustmentList", item.ItemChargeAdjustmentList)
+		l.addFees(itemEntry, prefix+".ItemFeeList", item.ItemFeeList)
+		l.addFees(itemEntry, prefix+".ItemFeeAdjustmentList", item.ItemFeeAdjustmentList)
+		l.addTaxesWithheld(itemEntry, prefix+".ItemTaxWithheldList", item.ItemTaxWithheldList)
+		l.addPromotions(itemEntry, prefix+".PromotionList", item.PromotionList)
+		l.addPromotions(itemEntry, prefix+".PromotionAdjustmentList", item.PromotionAdjustmentList)
+		l.addOther(itemEntry, prefix+".CostOfPointsGranted", item.CostOfPointsGranted)
+		l.addOther(itemEntry, prefix+".CostOfPointsReturned", item.CostOfPointsReturned)
+	}
+}
+
+func (l *ledger) addCharges(entry LedgerEntry, path string, charges []ChargeComponent) {
+	for i := range charges {
+		l.addCharge(entry, fmt.Sprintf("%s[%d]", path, i), &charges[i])
+	}
+}
+
+func (l *ledger) addCharge(entry LedgerEntry, path string, charge *ChargeComponent) {
+	if charge != nil {
+		l.add(entry, path, AmountKindCharge, str(charge.ChargeType), charge.ChargeAmount)
+	}
+}
+
+func (l *ledger) addFees(entry LedgerEntry, path string, fees []FeeComponent) {
+	for i := range fees {
+		l.addFee(entry, fmt.Sprintf("%s[%d]", path, i), &fees[i])
+	}
+}
+
+func (l *ledger) addFee(entry LedgerEntry, path string, fee *FeeComponent) {
+	if fee != nil {
+		l.add(entry, path, AmountKindFee, str(fee.FeeType), fee.FeeAmount)
+	}
+}
+
+func (l *ledger) addPromotions(entry LedgerEntry, path string, promotions []Promotion) {
+	for i, p := range promotions {
+		promotionEntry := entry
+		if p.PromotionId != nil {
+			promotionEntry.SourceID = *p.PromotionId
+		}
+		l.add(promotionEntry, fmt.Sprintf("%s[%d]", path, i), AmountKindPromotion, str(p.PromotionType), p.PromotionAmount)
+	}
+}
+
+func (l *ledger) addTaxesWithheld(entry LedgerEntry, path string, components []TaxWithheldComponent) {
+	for i, component := range components {
+		for j, tax := range component.TaxesWithheld {
+			l.add(entry, fmt.Sprintf("%s[%d].TaxesWithheld[%d]", path, i, j), AmountKindTaxWithheld, str(tax.ChargeType), tax.ChargeAmount)
+		}
+	}
+}
+
+// addOther adds an amount without component. The last segment of the path is used as AmountType.
+func (l *ledger) addOther(entry LedgerEntry, path string, amount *Currency) {
+	l.add(entry, path, AmountKindOther, lastSegment(path), amount)
+}
+
+func (l *ledger) add(entry LedgerEntry, path string, kind AmountKind, amountType string, amount *Currency) {
+	if amount == nil || amount.CurrencyAmount == nil {
+		return
+	}
+	entry.Source += path
+	entry.AmountKind = kind
+	entry.AmountType = amountType
+	entry.Amount = *amount.CurrencyAmount
+	entry.CurrencyCode = amount.Code()
+	l.entries = append(l.entries, entry)
+}
+
+func lastSegment(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '.' {
+			return path[i+1:]
+		}
+	}
+	return path
+}
+
+func str[T ~string](s *T) string {
+	if s == nil {
+		return ""
+	}
+	return string(*s)
+}
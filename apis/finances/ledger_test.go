@@ -0,0 +1,86 @@
+package finances
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinancialEvents_Ledger(t *testing.T) {
+	var events FinancialEvents
+	err := json.Unmarshal([]byte(`{
+		"ShipmentEventList": [{
+			"AmazonOrderId": "303-1234567-1234567",
+			"MarketplaceName": "Amazon.de",
+			"PostedDate": "2026-01-15T10:00:00Z",
+			"ShipmentItemList": [{
+				"SellerSKU": "SKU-1",
+				"OrderItemId": "11111111111111",
+				"ItemChargeList": [
+					{"ChargeType": "Principal", "ChargeAmount": {"CurrencyCode": "EUR", "CurrencyAmount": 19.99}},
+					{"ChargeType": "Tax", "ChargeAmount": {"CurrencyCode": "EUR", "CurrencyAmount": 3.80}}
+				],
+				"ItemFeeList": [
+					{"FeeType": "Commission", "FeeAmount": {"CurrencyCode": "EUR", "CurrencyAmount": -3.00}}
+				],
+				"PromotionList": [
+					{"PromotionType": "PromotionMetaDataDefinitionValue", "PromotionId": "PROMO-1", "PromotionAmount": {"CurrencyCode": "EUR", "CurrencyAmount": -1.00}}
+				]
+			}]
+		}],
+		"ServiceFeeEventList": [{
+			"FeeReason": "FBA storage",
+			"FeeList": [{"FeeType": "FBAStorageFee", "FeeAmount": {"CurrencyCode": "EUR", "CurrencyAmount": -0.45}}]
+		}],
+		"CouponPaymentEventList": [{
+			"CouponId": "COUPON-1",
+			"PostedDate": "2026-01-16T10:00:00Z",
+			"FeeComponent": {"FeeType": "CouponRedemptionFee", "FeeAmount": {"CurrencyCode": "EUR", "CurrencyAmount": -0.60}},
+			"TotalAmount": {"CurrencyCode": "EUR", "CurrencyAmount": -0.60}
+		}],
+		"ProductAdsPaymentEventList": [{
+			"postedDate": "2026-01-17T10:00:00Z",
+			"invoiceId": "INV-1",
+			"baseValue": {"CurrencyCode": "EUR", "CurrencyAmount": -10.00},
+			"taxValue": {"CurrencyCode": "EUR", "CurrencyAmount": -1.90},
+			"transactionValue": {"CurrencyCode": "EUR", "CurrencyAmount": -11.90}
+		}]
+	}`), &events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := events.Ledger()
+
+	assert.Len(t, entries, 8)
+	assert.Equal(t, LedgerEntry{
+		PostedDate:   time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+		EventType:    EventTypeShipment,
+		OrderID:      "303-1234567-1234567",
+		OrderItemID:  "11111111111111",
+		SKU:          "SKU-1",
+		Marketplace:  "Amazon.de",
+		AmountKind:   AmountKindFee,
+		AmountType:   "Commission",
+		Amount:       apis.MustParseAmount("-3.00"),
+		CurrencyCode: "EUR",
+		Source:       "ShipmentEventList[0].ShipmentItemList[0].ItemFeeList[0]",
+	}, entries[2])
+	assert.Equal(t, AmountKindPromotion, entries[3].AmountKind)
+	assert.Equal(t, "PROMO-1", entries[3].SourceID)
+	assert.Equal(t, "baseValue", entries[4].AmountType)
+	assert.Equal(t, "INV-1", entries[5].SourceID)
+	assert.Equal(t, "ServiceFeeEventList[0].FeeList[0]", entries[6].Source)
+	assert.True(t, entries[6].PostedDate.IsZero())
+	assert.Equal(t, "CouponPaymentEventList[0].FeeComponent", entries[7].Source)
+
+	// totals are skipped, so the entries sum up to the net amount
+	sum := apis.Amount{}
+	for _, e := range entries {
+		sum = sum.Add(e.Amount)
+	}
+	assert.Equal(t, "6.84", sum.String())
+}
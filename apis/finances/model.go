@@ -1,7 +1,6 @@
 package finances
 
 import (
-	"encoding/json"
 	"net/url"
 	"strconv"
 	"time"
@@ -86,9 +85,9 @@ func (f *ListFinancialEventsFilter) GetQuery() url.Values {
 // AdjustmentEvent An adjustment to the seller's account.
 type AdjustmentEvent struct {
 	// The type of adjustment.  Possible values:  * FBAInventoryReimbursement - An FBA inventory reimbursement to a seller's account. This occurs if a seller's inventory is damaged.  * ReserveEvent - A reserve event that is generated at the time of a settlement period closing. This occurs when some money from a seller's account is held back.  * PostageBilling - The amount paid by a seller for shipping labels.  * PostageRefund - The reimbursement of shipping labels purchased for orders that were canceled or refunded.  * LostOrDamagedReimbursement - An Amazon Easy Ship reimbursement to a seller's account for a package that we lost or damaged.  * CanceledButPickedUpReimbursement - An Amazon Easy Ship reimbursement to a seller's account. This occurs when a package is picked up and the order is subsequently canceled. This value is used only in the India marketplace.  * ReimbursementClawback - An Amazon Easy Ship reimbursement clawback from a seller's account. This occurs when a prior reimbursement is reversed. This value is used only in the India marketplace.  * SellerRewards - An award credited to a seller's account for their participation in an offer in the Seller Rewards program. Applies only to the India marketplace.
-	AdjustmentType   *string    `json:"AdjustmentType,omitempty"`
-	PostedDate       *time.Time `json:"PostedDate,omitempty"`
-	AdjustmentAmount *Currency  `json:"AdjustmentAmount,omitempty"`
+	AdjustmentType   *AdjustmentType `json:"AdjustmentType,omitempty"`
+	PostedDate       *time.Time      `json:"PostedDate,omitempty"`
+	AdjustmentAmount *Currency       `json:"AdjustmentAmount,omitempty"`
 	// A list of information about items in an adjustment to the seller's account.
 	AdjustmentItemList []AdjustmentItem `json:"AdjustmentItemList,omitempty"`
 }
@@ -113,7 +112,7 @@ type AdjustmentItem struct {
 type Currency struct {
 	// The three-digit currency code in ISO 4217 format.
 	CurrencyCode   *string      `json:"CurrencyCode,omitempty"`
-	CurrencyAmount *json.Number `json:"CurrencyAmount,omitempty"`
+	CurrencyAmount *apis.Amount `json:"CurrencyAmount,omitempty"`
 }
 
 // AffordabilityExpenseEvent An expense related to an affordability promotion.
@@ -135,8 +134,8 @@ type AffordabilityExpenseEvent struct {
 // ChargeComponent A charge on the seller's account.  Possible values:  * Principal - The selling price of the order item, equal to the selling price of the item multiplied by the quantity ordered.  * Tax - The tax collected by the seller on the Principal.  * MarketplaceFacilitatorTax-Principal - The tax withheld on the Principal.  * MarketplaceFacilitatorTax-Shipping - The tax withheld on the ShippingCharge.  * MarketplaceFacilitatorTax-Giftwrap - The tax withheld on the Giftwrap charge.  * MarketplaceFacilitatorTax-Other - The tax withheld on other miscellaneous charges.  * Discount - The promotional discount for an order item.  * TaxDiscount - The tax amount deducted for promotional rebates.  * CODItemCharge - The COD charge for an order item.  * CODItemTaxCharge - The tax collected by the seller on a CODItemCharge.  * CODOrderCharge - The COD charge for an order.  * CODOrderTaxCharge - The tax collected by the seller on a CODOrderCharge.  * CODShippingCharge - Shipping charges for a COD order.  * CODShippingTaxCharge - The tax collected by the seller on a CODShippingCharge.  * ShippingCharge - The shipping charge.  * ShippingTax - The tax collected by the seller on a ShippingCharge.  * Goodwill - The amount given to a buyer as a gesture of goodwill or to compensate for pain and suffering in the buying experience.  * Giftwrap - The gift wrap charge.  * GiftwrapTax - The tax collected by the seller on a Giftwrap charge.  * RestockingFee - The charge applied to the buyer when returning a product in certain categories.  * ReturnShipping - The amount given to the buyer to compensate for shipping the item back in the event we are at fault.  * PointsFee - The value of Amazon Points deducted from the refund if the buyer does not have enough Amazon Points to cover the deduction.  * GenericDeduction - A generic bad debt deduction.  * FreeReplacementReturnShipping - The compensation for return shipping when a buyer receives the wrong item, requests a free replacement, and returns the incorrect item.  * PaymentMethodFee - The fee collected for certain payment methods in certain marketplaces.  * ExportCharge - The export duty that is charged when an item is shipped to an international destination as part of the Amazon Global program.  * SAFE-TReimbursement - The SAFE-T claim amount for the item.  * TCS-CGST - Tax Collected at Source (TCS) for Central Goods and Services Tax (CGST).  * TCS-SGST - Tax Collected at Source for State Goods and Services Tax (SGST).  * TCS-IGST - Tax Collected at Source for Integrated Goods and Services Tax (IGST).  * TCS-UTGST - Tax Collected at Source for Union Territories Goods and Services Tax (UTGST).
 type ChargeComponent struct {
 	// The type of charge.
-	ChargeType   *string   `json:"ChargeType,omitempty"`
-	ChargeAmount *Currency `json:"ChargeAmount,omitempty"`
+	ChargeType   *ChargeType `json:"ChargeType,omitempty"`
+	ChargeAmount *Currency   `json:"ChargeAmount,omitempty"`
 }
 
 // ChargeInstrument A payment instrument.
@@ -203,7 +202,7 @@ type FBALiquidationEvent struct {
 // FeeComponent A fee associated with the event.
 type FeeComponent struct {
 	// The type of fee. For more information about Selling on Amazon fees, see [Selling on Amazon Fee Schedule](https://sellercentral.amazon.com/gp/help/200336920) on Seller Central. For more information about Fulfillment by Amazon fees, see [FBA features, services and fees](https://sellercentral.amazon.com/gp/help/201074400) on Seller Central.
-	FeeType   *string   `json:"FeeType,omitempty"`
+	FeeType   *FeeType  `json:"FeeType,omitempty"`
 	FeeAmount *Currency `json:"FeeAmount,omitempty"`
 }
 
@@ -212,7 +211,7 @@ type FinancialEventGroup struct {
 	// A unique identifier for the financial event group.
 	FinancialEventGroupId *string `json:"FinancialEventGroupId,omitempty"`
 	// The processing status of the financial event group indicates whether the balance of the financial event group is settled.  Possible values:  * Open  * Closed
-	ProcessingStatus *string `json:"ProcessingStatus,omitempty"`
+	ProcessingStatus *ProcessingStatus `json:"ProcessingStatus,omitempty"`
 	// The status of the fund transfer.
 	FundTransferStatus *string    `json:"FundTransferStatus,omitempty"`
 	OriginalTotal      *Currency  `json:"OriginalTotal,omitempty"`
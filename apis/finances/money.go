@@ -0,0 +1,64 @@
+package finances
+
+import "github.com/fond-of-vertigo/amazon-sp-api/apis"
+
+// NewCurrency returns a Currency with the given code and amount.
+func NewCurrency(currencyCode string, amount apis.Amount) Currency {
+	return Currency{CurrencyCode: &currencyCode, CurrencyAmount: &amount}
+}
+
+// Code returns the currency code or an empty string if it is not set.
+func (c *Currency) Code() string {
+	if c == nil || c.CurrencyCode == nil {
+		return ""
+	}
+	return *c.CurrencyCode
+}
+
+// Amount returns the amount or 0 if it is not set.
+func (c *Currency) Amount() apis.Amount {
+	if c == nil || c.CurrencyAmount == nil {
+		return apis.Amount{}
+	}
+	return *c.CurrencyAmount
+}
+
+// Add returns c + other. An unset currency code is treated as the code of the other Currency.
+// It fails with apis.ErrCurrencyMismatch if both codes are set and differ.
+func (c *Currency) Add(other *Currency) (Currency, error) {
+	code := c.Code()
+	if code == "" {
+		code = other.Code()
+	} else if other.Code() != "" {
+		if err := apis.CheckSameCurrency(code, other.Code()); err != nil {
+			return Currency{}, err
+		}
+	}
+	return NewCurrency(code, c.Amount().Add(other.Amount())), nil
+}
+
+// Sub returns c - other. See Add for the handling of currency codes.
+func (c *Currency) Sub(other *Currency) (Currency, error) {
+	negated := other.Neg()
+	return c.Add(&negated)
+}
+
+// Neg returns -c.
+func (c *Currency) Neg() Currency {
+	return NewCurrency(c.Code(), c.Amount().Neg())
+}
+
+// Cmp compares the amounts like apis.Amount.Cmp. See Add for the handling of currency codes.
+func (c *Currency) Cmp(other *Currency) (int, error) {
+	if c.Code() != "" && other.Code() != "" {
+		if err := apis.CheckSameCurrency(c.Code(), other.Code()); err != nil {
+			return 0, err
+		}
+	}
+	return c.Amount().Cmp(other.Amount()), nil
+}
+
+// Round rounds the amount to the minor units of the currency.
+func (c *Currency) Round() Currency {
+	return NewCurrency(c.Code(), c.Amount().RoundToCurrency(c.Code()))
+}
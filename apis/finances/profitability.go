@@ -0,0 +1,168 @@
+package finances
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+)
+
+// ProfitBreakdown sums up the money movements of an order or order item by category.
+// Charges are positive, fees, promotions and refunds are usually negative.
+type ProfitBreakdown struct {
+	Principal apis.Amount
+	// Tax contains tax charges and taxes withheld by Amazon as marketplace facilitator.
+	Tax        apis.Amount
+	Shipping   apis.Amount
+	AmazonFees apis.Amount
+	Promotions apis.Amount
+	// Refunds contains all amounts of refund, guarantee claim and chargeback events.
+	Refunds apis.Amount
+	// Other contains all amounts which do not fit into the other categories, e.g. gift wrap charges.
+	Other apis.Amount
+}
+
+// Net returns the sum of all categories.
+func (b ProfitBreakdown) Net() apis.Amount {
+	return b.Principal.Add(b.Tax).Add(b.Shipping).Add(b.AmazonFees).Add(b.Promotions).Add(b.Refunds).Add(b.Other)
+}
+
+func (b *ProfitBreakdown) add(entry LedgerEntry) {
+	switch entry.EventType {
+	case EventTypeRefund, EventTypeGuaranteeClaim, EventTypeChargeback:
+		b.Refunds = b.Refunds.Add(entry.Amount)
+		return
+	}
+
+	switch {
+	case entry.AmountKind == AmountKindFee:
+		b.AmazonFees = b.AmazonFees.Add(entry.Amount)
+	case entry.AmountKind == AmountKindPromotion:
+		b.Promotions = b.Promotions.Add(entry.Amount)
+	case entry.AmountKind == AmountKindTaxWithheld, entry.AmountKind == AmountKindCharge && strings.Contains(entry.AmountType, "Tax"):
+		b.Tax = b.Tax.Add(entry.Amount)
+	case entry.AmountKind == AmountKindCharge && entry.AmountType == string(ChargeTypePrincipal):
+		b.Principal = b.Principal.Add(entry.Amount)
+	case entry.AmountKind == AmountKindCharge && entry.AmountType == string(ChargeTypeShippingCharge):
+		b.Shipping = b.Shipping.Add(entry.Amount)
+	default:
+		b.Other = b.Other.Add(entry.Amount)
+	}
+}
+
+// ItemProfitability is the ProfitBreakdown of an order item.
+type ItemProfitability struct {
+	OrderItemID     string
+	SKU             string
+	QuantityOrdered int
+	// Proceeds is the ProceedsTotal of the order item as returned by the orders API.
+	Proceeds *orders.Money
+	ProfitBreakdown
+}
+
+// OrderProfitability is the ProfitBreakdown of an order per item.
+type OrderProfitability struct {
+	OrderID      string
+	CurrencyCode string
+	Items        []ItemProfitability
+	// Unallocated contains the amounts which cannot be matched to an order item, e.g. order level fees.
+	Unallocated ProfitBreakdown
+}
+
+// Net returns the sum of all items and the unallocated amounts.
+func (p *OrderProfitability) Net() apis.Amount {
+	net := p.Unallocated.Net()
+	for _, item := range p.Items {
+		net = net.Add(item.Net())
+	}
+	return net
+}
+
+// GetOrderProfitability fetches the order including its proceeds and all its financial events
+// and combines them with CalculateOrderProfitability.
+func (a *API) GetOrderProfitability(ordersAPI *orders.API, orderID string) (*OrderProfitability, error) {
+	orderResp, err := ordersAPI.GetOrder(orderID, []orders.IncludedData{orders.IncludedDataProceeds}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if orderResp.ResponseBody == nil {
+		return nil, errors.New("getOrder returned an empty response")
+	}
+
+	maxResults := 100
+	filter := &ListFinancialEventsByIDFilter{MaxResultsPerPage: &maxResults}
+	var events []FinancialEvents
+	for {
+		resp, err := a.ListFinancialEventsByOrderID(orderID, filter)
+		if err != nil {
+			return nil, err
+		}
+		if resp.ResponseBody == nil || resp.ResponseBody.Payload == nil {
+			return nil, errors.New("listFinancialEventsByOrderId returned an empty response")
+		}
+		payload := resp.ResponseBody.Payload
+		if payload.FinancialEvents != nil {
+			events = append(events, *payload.FinancialEvents)
+		}
+		if payload.NextToken == nil || *payload.NextToken == "" {
+			break
+		}
+		filter = &ListFinancialEventsByIDFilter{MaxResultsPerPage: &maxResults, NextToken: payload.NextToken}
+	}
+	return CalculateOrderProfitability(orderResp.ResponseBody.Order, events)
+}
+
+// CalculateOrderProfitability matches the ledger entries of the financial events to the items of
+// the order, by order item ID first and by SKU second, and sums them up per item and category.
+// Entries of other orders and zero amounts without currency are ignored. It fails with apis.ErrCurrencyMismatch if the
+// entries have different currencies.
+func CalculateOrderProfitability(order orders.Order, events []FinancialEvents) (*OrderProfitability, error) {
+	p := &OrderProfitability{
+		OrderID: order.OrderID,
+		Items:   make([]ItemProfitability, len(order.OrderItems)),
+	}
+	itemsByID := map[string]*ItemProfitability{}
+	itemsBySKU := map[string]*ItemProfitability{}
+	for i, orderItem := range order.OrderItems {
+		item := &p.Items[i]
+		item.OrderItemID = orderItem.OrderItemID
+		item.SKU = orderItem.Product.SellerSKU
+		item.QuantityOrdered = orderItem.QuantityOrdered
+		if orderItem.Proceeds != nil {
+			item.Proceeds = orderItem.Proceeds.ProceedsTotal
+		}
+		itemsByID[item.OrderItemID] = item
+		if _, ok := itemsBySKU[item.SKU]; !ok && item.SKU != "" {
+			itemsBySKU[item.SKU] = item
+		}
+	}
+
+	for i := range events {
+		for _, entry := range events[i].Ledger() {
+			if entry.OrderID != "" && entry.OrderID != order.OrderID {
+				continue
+			}
+			// zero value events contain zero amounts without currency
+			if entry.CurrencyCode == "" && entry.Amount.IsZero() {
+				continue
+			}
+			if p.CurrencyCode == "" {
+				p.CurrencyCode = entry.CurrencyCode
+			} else if err := apis.CheckSameCurrency(p.CurrencyCode, entry.CurrencyCode); err != nil {
+				return nil, err
+			}
+
+			item, ok := itemsByID[entry.OrderItemID]
+			if !ok || entry.OrderItemID == "" {
+				item, ok = itemsBySKU[entry.SKU]
+			}
+			if ok {
+				item.add(entry)
+			} else {
+				p.Unallocated.add(entry)
+			}
+		}
+	}
+	return p, nil
+}
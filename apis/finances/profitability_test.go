@@ -0,0 +1,93 @@
+package finances
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateOrderProfitability(t *testing.T) {
+	money := func(currencyCode, amount string) *Currency {
+		c := NewCurrency(currencyCode, apis.MustParseAmount(amount))
+		return &c
+	}
+	charge := func(chargeType ChargeType, amount string) ChargeComponent {
+		return ChargeComponent{ChargeType: &chargeType, ChargeAmount: money("EUR", amount)}
+	}
+	fee := func(feeType FeeType, amount string) FeeComponent {
+		return FeeComponent{FeeType: &feeType, FeeAmount: money("EUR", amount)}
+	}
+	str := func(s string) *string { return &s }
+
+	order := orders.Order{
+		OrderID: "303-1234567-1234567",
+		OrderItems: []orders.OrderItem{
+			{OrderItemID: "1001", QuantityOrdered: 1, Product: orders.ItemProduct{SellerSKU: "SKU-1"}},
+			{OrderItemID: "1002", QuantityOrdered: 2, Product: orders.ItemProduct{SellerSKU: "SKU-2"}},
+		},
+	}
+	events := []FinancialEvents{{
+		ShipmentEventList: []ShipmentEvent{{
+			AmazonOrderId:   str("303-1234567-1234567"),
+			ShipmentFeeList: []FeeComponent{fee("FBAInboundTransportationFee", "-0.50")},
+			ShipmentItemList: []ShipmentItem{
+				{
+					OrderItemId:    str("1001"),
+					SellerSKU:      str("SKU-1"),
+					ItemChargeList: []ChargeComponent{charge("Principal", "16.80"), charge("Tax", "3.19"), charge("ShippingCharge", "4.90")},
+					ItemFeeList:    []FeeComponent{fee("Commission", "-3.00")},
+					PromotionList:  []Promotion{{PromotionAmount: money("EUR", "-4.90")}},
+				},
+				{
+					OrderItemId:    str("1002"),
+					SellerSKU:      str("SKU-2"),
+					ItemChargeList: []ChargeComponent{charge("Principal", "20.00"), charge("GiftWrap", "2.00")},
+				},
+			},
+		}},
+		RefundEventList: []ShipmentEvent{{
+			AmazonOrderId: str("303-1234567-1234567"),
+			ShipmentItemAdjustmentList: []ShipmentItem{{
+				SellerSKU:                str("SKU-2"),
+				ItemChargeAdjustmentList: []ChargeComponent{charge("Principal", "-10.00")},
+			}},
+		}},
+		ServiceFeeEventList: []ServiceFeeEvent{{
+			AmazonOrderId: str("404-0000000-0000000"),
+			FeeList:       []FeeComponent{fee("OtherOrderFee", "-99.00")},
+		}},
+	}}
+
+	got, err := CalculateOrderProfitability(order, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "EUR", got.CurrencyCode)
+	item1, item2 := got.Items[0], got.Items[1]
+	assert.Equal(t, "16.80", item1.Principal.String())
+	assert.Equal(t, "3.19", item1.Tax.String())
+	assert.Equal(t, "4.90", item1.Shipping.String())
+	assert.Equal(t, "-3.00", item1.AmazonFees.String())
+	assert.Equal(t, "-4.90", item1.Promotions.String())
+	assert.Equal(t, "16.99", item1.Net().String())
+	assert.Equal(t, "20.00", item2.Principal.String())
+	assert.Equal(t, "2.00", item2.Other.String())
+	assert.Equal(t, "-10.00", item2.Refunds.String())
+	assert.Equal(t, "-0.50", got.Unallocated.AmazonFees.String())
+	assert.Equal(t, "28.49", got.Net().String())
+
+	// zero amounts without currency don't fail the calculation
+	events[0].ShipmentEventList[0].ShipmentFeeList = append(events[0].ShipmentEventList[0].ShipmentFeeList, FeeComponent{FeeAmount: &Currency{CurrencyAmount: &apis.Amount{}}})
+	got, err = CalculateOrderProfitability(order, events)
+	assert.NoError(t, err)
+	assert.Equal(t, "28.49", got.Net().String())
+
+	events[0].ServiceFeeEventList[0].AmazonOrderId = str("303-1234567-1234567")
+	events[0].ServiceFeeEventList[0].FeeList[0].FeeAmount = money("GBP", "-1.00")
+	_, err = CalculateOrderProfitability(order, events)
+	assert.True(t, errors.Is(err, apis.ErrCurrencyMismatch))
+}
@@ -0,0 +1,161 @@
+package finances
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+)
+
+// ReconciliationStatus is the result of a financial event group reconciliation.
+type ReconciliationStatus string
+
+const (
+	// ReconciliationMatched means the financial events sum up to the OriginalTotal of the group.
+	ReconciliationMatched ReconciliationStatus = "Matched"
+	// ReconciliationExplainedByBeginningBalance means the financial events plus the
+	// BeginningBalance of the group sum up to its OriginalTotal.
+	ReconciliationExplainedByBeginningBalance ReconciliationStatus = "ExplainedByBeginningBalance"
+	// ReconciliationMismatch means there is a residual which is not explained by the BeginningBalance.
+	ReconciliationMismatch ReconciliationStatus = "Mismatch"
+)
+
+// CategoryTotal is the sum of all ledger entries of a currency, event type and amount kind.
+type CategoryTotal struct {
+	CurrencyCode string
+	EventType    EventType
+	AmountKind   AmountKind
+	Amount       apis.Amount
+	Entries      int
+}
+
+// ReconciliationReport compares the financial events of a group with its totals.
+type ReconciliationReport struct {
+	FinancialEventGroupID string
+	Status                ReconciliationStatus
+	// CurrencyCode is the currency of the OriginalTotal of the group.
+	CurrencyCode     string
+	OriginalTotal    apis.Amount
+	BeginningBalance apis.Amount
+	// EventTotals is the sum of all financial events per currency.
+	EventTotals map[string]apis.Amount
+	// Categories are sorted by currency, event type and amount kind.
+	Categories []CategoryTotal
+	// Difference is the OriginalTotal minus the sum of the events in the group currency.
+	Difference apis.Amount
+	// Residual is the part of the Difference which is not explained by the BeginningBalance.
+	Residual apis.Amount
+	// Mismatches describes every finding which prevents the group from matching.
+	Mismatches []string
+}
+
+// ReconcileFinancialEventGroup pages through all financial events of the group and reconciles
+// their sum against the OriginalTotal and BeginningBalance of the group.
+func (a *API) ReconcileFinancialEventGroup(group FinancialEventGroup) (*ReconciliationReport, error) {
+	if group.FinancialEventGroupId == nil {
+		return nil, errors.New("financial event group has no FinancialEventGroupId")
+	}
+
+	maxResults := 100
+	filter := &ListFinancialEventsByIDFilter{MaxResultsPerPage: &maxResults}
+	var events []FinancialEvents
+	for {
+		resp, err := a.ListFinancialEventsByGroupID(*group.FinancialEventGroupId, filter)
+		if err != nil {
+			return nil, err
+		}
+		if resp.ResponseBody == nil || resp.ResponseBody.Payload == nil {
+			return nil, errors.New("listFinancialEventsByGroupId returned an empty response")
+		}
+		payload := resp.ResponseBody.Payload
+		if payload.FinancialEvents != nil {
+			events = append(events, *payload.FinancialEvents)
+		}
+		if payload.NextToken == nil || *payload.NextToken == "" {
+			break
+		}
+		filter = &ListFinancialEventsByIDFilter{MaxResultsPerPage: &maxResults, NextToken: payload.NextToken}
+	}
+	return Reconcile(group, events), nil
+}
+
+// Reconcile aggregates the ledger entries of all pages of financial events of the group by currency
+// and category and compares the total in the group currency with the OriginalTotal of the group.
+// A difference equal to the BeginningBalance is considered as explained.
+func Reconcile(group FinancialEventGroup, events []FinancialEvents) *ReconciliationReport {
+	report := &ReconciliationReport{
+		FinancialEventGroupID: str(group.FinancialEventGroupId),
+		CurrencyCode:          group.OriginalTotal.Code(),
+		OriginalTotal:         group.OriginalTotal.Amount(),
+		BeginningBalance:      group.BeginningBalance.Amount(),
+		EventTotals:           map[string]apis.Amount{},
+	}
+
+	type category struct {
+		currencyCode string
+		eventType    EventType
+		amountKind   AmountKind
+	}
+	categories := map[category]*CategoryTotal{}
+	for i := range events {
+		for _, entry := range events[i].Ledger() {
+			report.EventTotals[entry.CurrencyCode] = report.EventTotals[entry.CurrencyCode].Add(entry.Amount)
+
+			key := category{entry.CurrencyCode, entry.EventType, entry.AmountKind}
+			total, ok := categories[key]
+			if !ok {
+				total = &CategoryTotal{CurrencyCode: key.currencyCode, EventType: key.eventType, AmountKind: key.amountKind}
+				categories[key] = total
+			}
+			total.Amount = total.Amount.Add(entry.Amount)
+			total.Entries++
+		}
+	}
+	for _, total := range categories {
+		report.Categories = append(report.Categories, *total)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		a, b := report.Categories[i], report.Categories[j]
+		if a.CurrencyCode != b.CurrencyCode {
+			return a.CurrencyCode < b.CurrencyCode
+		}
+		if a.EventType != b.EventType {
+			return a.EventType < b.EventType
+		}
+		return a.AmountKind < b.AmountKind
+	})
+
+	if group.OriginalTotal == nil || group.OriginalTotal.CurrencyAmount == nil {
+		report.Mismatches = append(report.Mismatches, "financial event group has no OriginalTotal")
+	}
+	if balanceCode := group.BeginningBalance.Code(); balanceCode != "" && report.CurrencyCode != "" {
+		if err := apis.CheckSameCurrency(report.CurrencyCode, balanceCode); err != nil {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("BeginningBalance: %v", err))
+		}
+	}
+	for currencyCode, total := range report.EventTotals {
+		if currencyCode != report.CurrencyCode && !total.IsZero() {
+			report.Mismatches = append(report.Mismatches,
+				fmt.Sprintf("events contain %s %s which is not the group currency %s", total, currencyCode, report.CurrencyCode))
+		}
+	}
+	sort.Strings(report.Mismatches)
+
+	report.Difference = report.OriginalTotal.Sub(report.EventTotals[report.CurrencyCode])
+	report.Status = ReconciliationMatched
+	report.Residual = report.Difference
+	if !report.Difference.IsZero() {
+		report.Status = ReconciliationExplainedByBeginningBalance
+		report.Residual = report.Difference.Sub(report.BeginningBalance)
+		if !report.Residual.IsZero() {
+			report.Status = ReconciliationMismatch
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("residual of %s %s is not explained by the BeginningBalance of %s",
+				report.Residual, report.CurrencyCode, report.BeginningBalance))
+		}
+	}
+	if len(report.Mismatches) > 0 {
+		report.Status = ReconciliationMismatch
+	}
+	return report
+}
@@ -0,0 +1,67 @@
+package finances
+
+import (
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	eur := func(amount string) *Currency {
+		c := NewCurrency("EUR", apis.MustParseAmount(amount))
+		return &c
+	}
+	principal, commission := ChargeTypePrincipal, FeeTypeCommission
+	events := []FinancialEvents{
+		{ShipmentEventList: []ShipmentEvent{{
+			ShipmentItemList: []ShipmentItem{{
+				ItemChargeList: []ChargeComponent{{ChargeType: &principal, ChargeAmount: eur("100.00")}},
+				ItemFeeList:    []FeeComponent{{FeeType: &commission, FeeAmount: eur("-15.00")}},
+			}},
+		}}},
+		{RefundEventList: []ShipmentEvent{{
+			ShipmentItemAdjustmentList: []ShipmentItem{{
+				ItemChargeAdjustmentList: []ChargeComponent{{ChargeType: &principal, ChargeAmount: eur("-20.00")}},
+			}},
+		}}},
+	}
+	groupID := "GROUP-1"
+
+	tests := []struct {
+		name             string
+		originalTotal    *Currency
+		beginningBalance *Currency
+		wantStatus       ReconciliationStatus
+		wantResidual     string
+	}{
+		{name: "Matched", originalTotal: eur("65.00"), wantStatus: ReconciliationMatched, wantResidual: "0.00"},
+		{name: "Explained by beginning balance", originalTotal: eur("75.00"), beginningBalance: eur("10.00"),
+			wantStatus: ReconciliationExplainedByBeginningBalance, wantResidual: "0.00"},
+		{name: "Unexplained residual", originalTotal: eur("80.00"), beginningBalance: eur("10.00"),
+			wantStatus: ReconciliationMismatch, wantResidual: "5.00"},
+		{name: "Missing original total", wantStatus: ReconciliationMismatch, wantResidual: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := FinancialEventGroup{
+				FinancialEventGroupId: &groupID,
+				OriginalTotal:         tt.originalTotal,
+				BeginningBalance:      tt.beginningBalance,
+			}
+
+			report := Reconcile(group, events)
+
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantResidual, report.Residual.String())
+			assert.Equal(t, tt.wantStatus != ReconciliationMismatch, len(report.Mismatches) == 0)
+		})
+	}
+
+	report := Reconcile(FinancialEventGroup{OriginalTotal: eur("65.00")}, events)
+	assert.Equal(t, []CategoryTotal{
+		{CurrencyCode: "EUR", EventType: EventTypeRefund, AmountKind: AmountKindCharge, Amount: apis.MustParseAmount("-20.00"), Entries: 1},
+		{CurrencyCode: "EUR", EventType: EventTypeShipment, AmountKind: AmountKindCharge, Amount: apis.MustParseAmount("100.00"), Entries: 1},
+		{CurrencyCode: "EUR", EventType: EventTypeShipment, AmountKind: AmountKindFee, Amount: apis.MustParseAmount("-15.00"), Entries: 1},
+	}, report.Categories)
+}
@@ -0,0 +1,255 @@
+package v20240619
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// TransactionStatus The status of a transaction.
+type TransactionStatus string
+
+const (
+	TransactionStatusDeferred         TransactionStatus = "DEFERRED"
+	TransactionStatusReleased         TransactionStatus = "RELEASED"
+	TransactionStatusDeferredReleased TransactionStatus = "DEFERRED_RELEASED"
+)
+
+// RelatedIdentifierName The name of an identifier related to a transaction.
+type RelatedIdentifierName string
+
+const (
+	RelatedIdentifierOrderID               RelatedIdentifierName = "ORDER_ID"
+	RelatedIdentifierShipmentID            RelatedIdentifierName = "SHIPMENT_ID"
+	RelatedIdentifierFinancialEventGroupID RelatedIdentifierName = "FINANCIAL_EVENT_GROUP_ID"
+	RelatedIdentifierRefundID              RelatedIdentifierName = "REFUND_ID"
+	RelatedIdentifierInvoiceID             RelatedIdentifierName = "INVOICE_ID"
+	RelatedIdentifierDisbursementID        RelatedIdentifierName = "DISBURSEMENT_ID"
+	RelatedIdentifierTransferID            RelatedIdentifierName = "TRANSFER_ID"
+	RelatedIdentifierDeferredTransactionID RelatedIdentifierName = "DEFERRED_TRANSACTION_ID"
+	RelatedIdentifierReleaseTransactionID  RelatedIdentifierName = "RELEASE_TRANSACTION_ID"
+	RelatedIdentifierSettlementID          RelatedIdentifierName = "SETTLEMENT_ID"
+)
+
+// ItemRelatedIdentifierName The name of an identifier related to a transaction item.
+type ItemRelatedIdentifierName string
+
+const (
+	ItemRelatedIdentifierOrderAdjustmentItemID ItemRelatedIdentifierName = "ORDER_ADJUSTMENT_ITEM_ID"
+	ItemRelatedIdentifierCouponID              ItemRelatedIdentifierName = "COUPON_ID"
+	ItemRelatedIdentifierRemovalShipmentItemID ItemRelatedIdentifierName = "REMOVAL_SHIPMENT_ITEM_ID"
+	ItemRelatedIdentifierTransactionID         ItemRelatedIdentifierName = "TRANSACTION_ID"
+)
+
+// ContextType The type of additional information of a transaction or item.
+type ContextType string
+
+const (
+	ContextTypeProduct   ContextType = "ProductContext"
+	ContextTypeAmazonPay ContextType = "AmazonPayContext"
+	ContextTypePayments  ContextType = "PaymentsContext"
+	ContextTypeDeferred  ContextType = "DeferredContext"
+	ContextTypeTimeRange ContextType = "TimeRangeContext"
+)
+
+// ListTransactionsFilter is used to filter transactions in the ListTransactions call.
+type ListTransactionsFilter struct {
+	// A date used for selecting transactions posted after (or at) a specified time. Required.
+	PostedAfter *apis.JsonTimeISO8601
+	// A date used for selecting transactions posted before (but not at) a specified time.
+	// Must be later than PostedAfter and no later than two minutes before the request was submitted.
+	// If PostedAfter and PostedBefore are more than 180 days apart, no transactions are returned.
+	PostedBefore *apis.JsonTimeISO8601
+	// The ID of the marketplace in which transactions are requested.
+	MarketplaceID *constants.MarketplaceID
+	// The status of the transactions to return.
+	TransactionStatus *TransactionStatus
+	// A string token returned in the response of your previous request.
+	NextToken *string
+}
+
+// GetQuery returns the query parameters for ListTransactionsFilter.
+func (f *ListTransactionsFilter) GetQuery() url.Values {
+	q := url.Values{}
+	if f.PostedAfter != nil {
+		q.Add("postedAfter", f.PostedAfter.String())
+	}
+	if f.PostedBefore != nil {
+		q.Add("postedBefore", f.PostedBefore.String())
+	}
+	if f.MarketplaceID != nil {
+		q.Add("marketplaceId", string(*f.MarketplaceID))
+	}
+	if f.TransactionStatus != nil {
+		q.Add("transactionStatus", string(*f.TransactionStatus))
+	}
+	if f.NextToken != nil {
+		q.Add("nextToken", *f.NextToken)
+	}
+
+	return q
+}
+
+// ListTransactionsResponse The response schema for the listTransactions operation.
+type ListTransactionsResponse struct {
+	Payload *TransactionsPayload `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// GetNextToken returns the token of the next page or nil if this is the last page.
+func (r *ListTransactionsResponse) GetNextToken() *string {
+	if r.Payload == nil || r.Payload.NextToken == nil || *r.Payload.NextToken == "" {
+		return nil
+	}
+	return r.Payload.NextToken
+}
+
+// TransactionsPayload The payload for the listTransactions operation.
+type TransactionsPayload struct {
+	// The response includes nextToken when the number of results exceeds the specified pageSize value.
+	NextToken *string `json:"nextToken,omitempty"`
+	// A list of transactions within the specified time period.
+	Transactions []Transaction `json:"transactions,omitempty"`
+}
+
+// Transaction All the information related to a transaction.
+type Transaction struct {
+	SellingPartnerMetadata *SellingPartnerMetadata `json:"sellingPartnerMetadata,omitempty"`
+	// Related business identifiers of the transaction.
+	RelatedIdentifiers []RelatedIdentifier `json:"relatedIdentifiers,omitempty"`
+	// The type of transaction, e.g. Shipment, Refund or ServiceFee.
+	TransactionType *string `json:"transactionType,omitempty"`
+	// The unique identifier of the transaction.
+	TransactionID *string `json:"transactionId,omitempty"`
+	// The status of the transaction.
+	TransactionStatus *TransactionStatus `json:"transactionStatus,omitempty"`
+	// Describes the reasons for the transaction.
+	Description *string `json:"description,omitempty"`
+	// The date and time when the transaction was posted.
+	PostedDate         *time.Time          `json:"postedDate,omitempty"`
+	TotalAmount        *Currency           `json:"totalAmount,omitempty"`
+	MarketplaceDetails *MarketplaceDetails `json:"marketplaceDetails,omitempty"`
+	// Additional information about the items in the transaction.
+	Items []Item `json:"items,omitempty"`
+	// Additional information about the transaction.
+	Contexts []Context `json:"contexts,omitempty"`
+	// A list of breakdowns that provide details on how the total amount is calculated for the transaction.
+	Breakdowns []Breakdown `json:"breakdowns,omitempty"`
+}
+
+// RelatedIdentifier returns the value of the first related identifier with the given name or nil if there is none.
+func (t *Transaction) RelatedIdentifier(name RelatedIdentifierName) *string {
+	for _, identifier := range t.RelatedIdentifiers {
+		if identifier.RelatedIdentifierName != nil && *identifier.RelatedIdentifierName == name {
+			return identifier.RelatedIdentifierValue
+		}
+	}
+	return nil
+}
+
+// SellingPartnerMetadata Metadata that describes the seller.
+type SellingPartnerMetadata struct {
+	// A unique seller identifier.
+	SellingPartnerID *string `json:"sellingPartnerId,omitempty"`
+	// The type of account in the transaction.
+	AccountType *string `json:"accountType,omitempty"`
+	// The identifier of the marketplace.
+	MarketplaceID *string `json:"marketplaceId,omitempty"`
+}
+
+// RelatedIdentifier Related business identifier of the transaction.
+type RelatedIdentifier struct {
+	// An enumerated set of related business identifier names.
+	RelatedIdentifierName *RelatedIdentifierName `json:"relatedIdentifierName,omitempty"`
+	// Corresponding value of RelatedIdentifierName.
+	RelatedIdentifierValue *string `json:"relatedIdentifierValue,omitempty"`
+}
+
+// MarketplaceDetails Information about the marketplace where the transaction occurred.
+type MarketplaceDetails struct {
+	// The identifier of the marketplace.
+	MarketplaceID *string `json:"marketplaceId,omitempty"`
+	// The name of the marketplace.
+	MarketplaceName *string `json:"marketplaceName,omitempty"`
+}
+
+// Item Additional information about the items in a transaction.
+type Item struct {
+	// A description of the items in a transaction.
+	Description *string `json:"description,omitempty"`
+	// Related business identifiers of the item.
+	RelatedIdentifiers []ItemRelatedIdentifier `json:"relatedIdentifiers,omitempty"`
+	TotalAmount        *Currency               `json:"totalAmount,omitempty"`
+	// A list of breakdowns that provide details on how the total amount is calculated for the item.
+	Breakdowns []Breakdown `json:"breakdowns,omitempty"`
+	// Additional information about the item.
+	Contexts []Context `json:"contexts,omitempty"`
+}
+
+// ItemRelatedIdentifier Related business identifiers of the item.
+type ItemRelatedIdentifier struct {
+	// Enumerated set of related item identifier names.
+	ItemRelatedIdentifierName *ItemRelatedIdentifierName `json:"itemRelatedIdentifierName,omitempty"`
+	// Corresponding value of ItemRelatedIdentifierName.
+	ItemRelatedIdentifierValue *string `json:"itemRelatedIdentifierValue,omitempty"`
+}
+
+// Breakdown Details about the movement of money in the financial transaction. Breakdowns are nested.
+type Breakdown struct {
+	// The type of charge, e.g. Tax, Principal, AmazonFees or ShippingCharge.
+	BreakdownType   *string   `json:"breakdownType,omitempty"`
+	BreakdownAmount *Currency `json:"breakdownAmount,omitempty"`
+	// A list of breakdowns that provide details on how the breakdown amount is calculated.
+	Breakdowns []Breakdown `json:"breakdowns,omitempty"`
+}
+
+// Context Additional information about a transaction or item. ContextType determines
+// which of the other fields are set.
+type Context struct {
+	ContextType *ContextType `json:"contextType,omitempty"`
+
+	// ProductContext: the Amazon Standard Identification Number (ASIN) of the item.
+	ASIN *string `json:"asin,omitempty"`
+	// ProductContext: the Stock Keeping Unit (SKU) of the item.
+	SKU *string `json:"sku,omitempty"`
+	// ProductContext: the quantity of the item shipped.
+	QuantityShipped *int32 `json:"quantityShipped,omitempty"`
+	// ProductContext: the fulfillment network of the item, e.g. AFN or MFN.
+	FulfillmentNetwork *string `json:"fulfillmentNetwork,omitempty"`
+
+	// AmazonPayContext: the name of the store that is related to the transaction.
+	StoreName *string `json:"storeName,omitempty"`
+	// AmazonPayContext: the transaction's order type.
+	OrderType *string `json:"orderType,omitempty"`
+	// AmazonPayContext: channel details of the related transaction.
+	Channel *string `json:"channel,omitempty"`
+
+	// PaymentsContext: the type of payment.
+	PaymentType *string `json:"paymentType,omitempty"`
+	// PaymentsContext: the method of payment.
+	PaymentMethod *string `json:"paymentMethod,omitempty"`
+	// PaymentsContext: the reference number of the payment.
+	PaymentReference *string `json:"paymentReference,omitempty"`
+	// PaymentsContext: the date of the payment.
+	PaymentDate *time.Time `json:"paymentDate,omitempty"`
+
+	// DeferredContext: the deferral policy applied on the transaction, e.g. B2B or DD7.
+	DeferralReason *string `json:"deferralReason,omitempty"`
+	// DeferredContext: the release date of the transaction.
+	MaturityDate *time.Time `json:"maturityDate,omitempty"`
+
+	// TimeRangeContext: the start time of the period.
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// TimeRangeContext: the end time of the period.
+	EndTime *time.Time `json:"endTime,omitempty"`
+}
+
+// Currency A currency type and amount.
+type Currency struct {
+	// The three-digit currency code in ISO 4217 format.
+	CurrencyCode   *string      `json:"currencyCode,omitempty"`
+	CurrencyAmount *apis.Amount `json:"currencyAmount,omitempty"`
+}
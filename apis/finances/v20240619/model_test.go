@@ -0,0 +1,56 @@
+package v20240619
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTransactionsFilter_GetQuery(t *testing.T) {
+	marketplaceID := constants.MarketplaceID("A1PA6795UKMFR9")
+	status := TransactionStatusDeferred
+	filter := &ListTransactionsFilter{
+		PostedAfter:       &apis.JsonTimeISO8601{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		MarketplaceID:     &marketplaceID,
+		TransactionStatus: &status,
+	}
+
+	assert.Equal(t, "marketplaceId=A1PA6795UKMFR9&postedAfter=2026-01-01T00%3A00%3A00Z&transactionStatus=DEFERRED",
+		filter.GetQuery().Encode())
+}
+
+func TestTransaction_Unmarshal(t *testing.T) {
+	var resp ListTransactionsResponse
+	err := json.Unmarshal([]byte(`{"payload": {"nextToken": "", "transactions": [{
+		"transactionId": "TX-1",
+		"transactionType": "Shipment",
+		"transactionStatus": "DEFERRED",
+		"postedDate": "2026-01-15T10:00:00Z",
+		"relatedIdentifiers": [{"relatedIdentifierName": "ORDER_ID", "relatedIdentifierValue": "303-1234567-1234567"}],
+		"totalAmount": {"currencyCode": "EUR", "currencyAmount": 16.99},
+		"breakdowns": [{
+			"breakdownType": "Sales",
+			"breakdownAmount": {"currencyCode": "EUR", "currencyAmount": 19.99},
+			"breakdowns": [{"breakdownType": "ProductCharges", "breakdownAmount": {"currencyCode": "EUR", "currencyAmount": 19.99}}]
+		}],
+		"contexts": [{"contextType": "DeferredContext", "deferralReason": "DD7", "maturityDate": "2026-01-22T10:00:00Z"}],
+		"items": [{"contexts": [{"contextType": "ProductContext", "asin": "B000000001", "sku": "SKU-1", "quantityShipped": 1}]}]
+	}]}}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, resp.GetNextToken())
+	tx := resp.Payload.Transactions[0]
+	assert.Equal(t, "303-1234567-1234567", *tx.RelatedIdentifier(RelatedIdentifierOrderID))
+	assert.Nil(t, tx.RelatedIdentifier(RelatedIdentifierRefundID))
+	assert.Equal(t, "16.99", tx.TotalAmount.CurrencyAmount.String())
+	assert.Equal(t, "ProductCharges", *tx.Breakdowns[0].Breakdowns[0].BreakdownType)
+	assert.Equal(t, ContextTypeDeferred, *tx.Contexts[0].ContextType)
+	assert.Equal(t, "DD7", *tx.Contexts[0].DeferralReason)
+	assert.Equal(t, "SKU-1", *tx.Items[0].Contexts[0].SKU)
+}
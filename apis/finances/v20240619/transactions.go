@@ -0,0 +1,40 @@
+package v20240619
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+)
+
+const pathPrefix = "/finances/2024-06-19"
+
+type API struct {
+	httpClient *httpx.Client
+}
+
+func NewAPI(httpClient *httpx.Client) *API {
+	return &API{
+		httpClient: httpClient,
+	}
+}
+
+// ListTransactions returns transactions for the given parameters. Unlike the v0 financial events,
+// the transactions include non-Amazon marketplaces and deferred transactions.
+// It may take up to 48 hours for transactions to appear.
+func (a *API) ListTransactions(filter *ListTransactionsFilter) (*apis.CallResponse[ListTransactionsResponse], error) {
+	if filter.PostedAfter == nil {
+		return nil, errors.New("postedAfter is required")
+	}
+	if filter.PostedBefore != nil && !filter.PostedBefore.After(filter.PostedAfter.Time) {
+		return nil, errors.New("postedBefore must be after postedAfter")
+	}
+
+	return apis.NewCall[ListTransactionsResponse](http.MethodGet, pathPrefix+"/transactions").
+		WithQueryParams(filter.GetQuery()).
+		WithRateLimit(0.5, time.Second).
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+}
@@ -0,0 +1,9 @@
+package apis
+
+import "github.com/fond-of-vertigo/amazon-sp-api/constants"
+
+// MarketplaceScoped is implemented by requests which target specific marketplaces.
+// It allows to route a request to the endpoint which serves its marketplaces.
+type MarketplaceScoped interface {
+	GetMarketplaceIDs() []constants.MarketplaceID
+}
@@ -0,0 +1,167 @@
+package notifications
+
+import "github.com/fond-of-vertigo/amazon-sp-api/apis"
+
+// NotificationType The type of notification.
+type NotificationType string
+
+const (
+	NotificationTypeAnyOfferChanged                  NotificationType = "ANY_OFFER_CHANGED"
+	NotificationTypeApplicationOAuthClientNewSecret  NotificationType = "APPLICATION_OAUTH_CLIENT_NEW_SECRET"
+	NotificationTypeB2BAnyOfferChanged               NotificationType = "B2B_ANY_OFFER_CHANGED"
+	NotificationTypeFeedProcessingFinished           NotificationType = "FEED_PROCESSING_FINISHED"
+	NotificationTypeFBAInventoryAvailabilityChanges  NotificationType = "FBA_INVENTORY_AVAILABILITY_CHANGES"
+	NotificationTypeFBAOutboundShipmentStatus        NotificationType = "FBA_OUTBOUND_SHIPMENT_STATUS"
+	NotificationTypeFulfillmentOrderStatus           NotificationType = "FULFILLMENT_ORDER_STATUS"
+	NotificationTypeItemProductTypeChange            NotificationType = "ITEM_PRODUCT_TYPE_CHANGE"
+	NotificationTypeListingsItemIssuesChange         NotificationType = "LISTINGS_ITEM_ISSUES_CHANGE"
+	NotificationTypeListingsItemStatusChange         NotificationType = "LISTINGS_ITEM_STATUS_CHANGE"
+	NotificationTypeOrderChange                      NotificationType = "ORDER_CHANGE"
+	NotificationTypePricingHealth                    NotificationType = "PRICING_HEALTH"
+	NotificationTypeReportProcessingFinished         NotificationType = "REPORT_PROCESSING_FINISHED"
+	NotificationTypeAccountStatusChanged             NotificationType = "ACCOUNT_STATUS_CHANGED"
+	NotificationTypeBrandedItemContentChange         NotificationType = "BRANDED_ITEM_CONTENT_CHANGE"
+	NotificationTypeProductTypeDefinitionsChange     NotificationType = "PRODUCT_TYPE_DEFINITIONS_CHANGE"
+	NotificationTypeMFNOrderStatusChange             NotificationType = "MFN_ORDER_STATUS_CHANGE"
+	NotificationTypeFeePromotion                     NotificationType = "FEE_PROMOTION"
+	NotificationTypeDetailPageTrafficEvent           NotificationType = "DETAIL_PAGE_TRAFFIC_EVENT"
+	NotificationTypeTransactionUpdate                NotificationType = "TRANSACTION_UPDATE"
+	NotificationTypeExternalFulfillmentShipmentEvent NotificationType = "EXTERNAL_FULFILLMENT_SHIPMENT_STATUS_CHANGE"
+)
+
+// EventFilterType The type of event filter, it must match the notification type.
+type EventFilterType string
+
+const (
+	EventFilterTypeAnyOfferChanged EventFilterType = "ANY_OFFER_CHANGED"
+	EventFilterTypeOrderChange     EventFilterType = "ORDER_CHANGE"
+)
+
+// Subscription Information about the subscription.
+type Subscription struct {
+	// The subscription identifier generated when the subscription is created.
+	SubscriptionID string `json:"subscriptionId"`
+	// The version of the payload object to be used in the notification.
+	PayloadVersion string `json:"payloadVersion"`
+	// The identifier for the destination where notifications will be delivered.
+	DestinationID       string               `json:"destinationId"`
+	ProcessingDirective *ProcessingDirective `json:"processingDirective,omitempty"`
+}
+
+// ProcessingDirective Additional information passed to the subscription to control the processing of notifications.
+type ProcessingDirective struct {
+	EventFilter *EventFilter `json:"eventFilter,omitempty"`
+}
+
+// EventFilter A notificationType specific filter.
+type EventFilter struct {
+	AggregationSettings *AggregationSettings `json:"aggregationSettings,omitempty"`
+	// A list of marketplace identifiers to subscribe to (e.g. ATVPDKIKX0DER).
+	MarketplaceIDs []string `json:"marketplaceIds,omitempty"`
+	// A list of order change types to subscribe to (e.g. BuyerRequestedChange).
+	OrderChangeTypes []string        `json:"orderChangeTypes,omitempty"`
+	EventFilterType  EventFilterType `json:"eventFilterType"`
+}
+
+// AggregationSettings A container that holds all of the necessary properties to configure the aggregation of notifications.
+type AggregationSettings struct {
+	// The supported time period for aggregation, FiveMinutes or TenMinutes.
+	AggregationTimePeriod string `json:"aggregationTimePeriod"`
+}
+
+// GetSubscriptionResponse The response schema for the getSubscription operation.
+type GetSubscriptionResponse struct {
+	Payload *Subscription `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// CreateSubscriptionRequest The request schema for the createSubscription operation.
+type CreateSubscriptionRequest struct {
+	// The version of the payload object to be used in the notification.
+	PayloadVersion string `json:"payloadVersion"`
+	// The identifier for the destination where notifications will be delivered.
+	DestinationID       string               `json:"destinationId"`
+	ProcessingDirective *ProcessingDirective `json:"processingDirective,omitempty"`
+}
+
+// CreateSubscriptionResponse The response schema for the createSubscription operation.
+type CreateSubscriptionResponse struct {
+	Payload *Subscription `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// Destination Information about the destination created when you call the createDestination operation.
+type Destination struct {
+	// The developer-defined name for this destination.
+	Name string `json:"name"`
+	// The destination identifier generated when you created the destination.
+	DestinationID string              `json:"destinationId"`
+	Resource      DestinationResource `json:"resource"`
+}
+
+// DestinationResource The destination resource types.
+type DestinationResource struct {
+	SQS         *SQSResource         `json:"sqs,omitempty"`
+	EventBridge *EventBridgeResource `json:"eventBridge,omitempty"`
+}
+
+// SQSResource The information required to create an Amazon Simple Queue Service (Amazon SQS) queue destination.
+type SQSResource struct {
+	// The Amazon Resource Name (ARN) associated with the SQS queue.
+	ARN string `json:"arn"`
+}
+
+// EventBridgeResource The Amazon EventBridge destination.
+type EventBridgeResource struct {
+	// The name of the partner event source associated with the destination.
+	Name string `json:"name"`
+	// The AWS region in which you receive the notifications.
+	Region string `json:"region"`
+	// The identifier for the AWS account that is responsible for charges related to receiving notifications.
+	AccountID string `json:"accountId"`
+}
+
+// DestinationResourceSpecification The information required to create a destination resource.
+// Applications should use one resource type (sqs or eventBridge) per destination.
+type DestinationResourceSpecification struct {
+	SQS         *SQSResource                      `json:"sqs,omitempty"`
+	EventBridge *EventBridgeResourceSpecification `json:"eventBridge,omitempty"`
+}
+
+// EventBridgeResourceSpecification The information required to create an Amazon EventBridge destination.
+type EventBridgeResourceSpecification struct {
+	// The AWS region in which you will be receiving the notifications.
+	Region string `json:"region"`
+	// The identifier for the AWS account that is responsible for charges related to receiving notifications.
+	AccountID string `json:"accountId"`
+}
+
+// CreateDestinationRequest The request schema for the createDestination operation.
+type CreateDestinationRequest struct {
+	ResourceSpecification DestinationResourceSpecification `json:"resourceSpecification"`
+	// A developer-defined name to help identify this destination.
+	Name string `json:"name"`
+}
+
+// CreateDestinationResponse The response schema for the createDestination operation.
+type CreateDestinationResponse struct {
+	Payload *Destination `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// GetDestinationResponse The response schema for the getDestination operation.
+type GetDestinationResponse struct {
+	Payload *Destination `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// GetDestinationsResponse The response schema for the getDestinations operation.
+type GetDestinationsResponse struct {
+	Payload []Destination `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
@@ -0,0 +1,109 @@
+package notifications
+
+import (
+	"encoding/json"
+	"go/types"
+	"net/http"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+)
+
+const pathPrefix = "/notifications/v1"
+
+type API struct {
+	httpClient *httpx.Client
+}
+
+func NewAPI(httpClient *httpx.Client) *API {
+	return &API{
+		httpClient: httpClient,
+	}
+}
+
+// GetSubscription returns information about the subscription of the selling partner for the notification type.
+func (a *API) GetSubscription(notificationType NotificationType) (*apis.CallResponse[GetSubscriptionResponse], error) {
+	return apis.NewCall[GetSubscriptionResponse](http.MethodGet, pathPrefix+"/subscriptions/"+string(notificationType)).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+}
+
+// CreateSubscription creates a subscription of the selling partner for the notification type
+// to be delivered to the destination.
+func (a *API) CreateSubscription(notificationType NotificationType, request *CreateSubscriptionRequest) (*apis.CallResponse[CreateSubscriptionResponse], error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return apis.NewCall[CreateSubscriptionResponse](http.MethodPost, pathPrefix+"/subscriptions/"+string(notificationType)).
+		WithBody(body).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+}
+
+// GetSubscriptionByID returns information about the subscription with the ID. This is a grantless operation.
+func (a *API) GetSubscriptionByID(notificationType NotificationType, subscriptionID string) (*apis.CallResponse[GetSubscriptionResponse], error) {
+	return apis.NewCall[GetSubscriptionResponse](http.MethodGet, pathPrefix+"/subscriptions/"+string(notificationType)+"/"+subscriptionID).
+		WithGrantlessScope(constants.ScopeNotifications).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+}
+
+// DeleteSubscriptionByID deletes the subscription with the ID. This is a grantless operation.
+func (a *API) DeleteSubscriptionByID(notificationType NotificationType, subscriptionID string) error {
+	_, err := apis.NewCall[types.Nil](http.MethodDelete, pathPrefix+"/subscriptions/"+string(notificationType)+"/"+subscriptionID).
+		WithGrantlessScope(constants.ScopeNotifications).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+	return err
+}
+
+// GetDestinations returns all destinations of the application. This is a grantless operation.
+func (a *API) GetDestinations() (*apis.CallResponse[GetDestinationsResponse], error) {
+	return apis.NewCall[GetDestinationsResponse](http.MethodGet, pathPrefix+"/destinations").
+		WithGrantlessScope(constants.ScopeNotifications).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+}
+
+// CreateDestination creates a destination resource to receive notifications. This is a grantless operation.
+func (a *API) CreateDestination(request *CreateDestinationRequest) (*apis.CallResponse[CreateDestinationResponse], error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return apis.NewCall[CreateDestinationResponse](http.MethodPost, pathPrefix+"/destinations").
+		WithBody(body).
+		WithGrantlessScope(constants.ScopeNotifications).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+}
+
+// GetDestination returns information about the destination with the ID. This is a grantless operation.
+func (a *API) GetDestination(destinationID string) (*apis.CallResponse[GetDestinationResponse], error) {
+	return apis.NewCall[GetDestinationResponse](http.MethodGet, pathPrefix+"/destinations/"+destinationID).
+		WithGrantlessScope(constants.ScopeNotifications).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+}
+
+// DeleteDestination deletes the destination with the ID. This is a grantless operation.
+func (a *API) DeleteDestination(destinationID string) error {
+	_, err := apis.NewCall[types.Nil](http.MethodDelete, pathPrefix+"/destinations/"+destinationID).
+		WithGrantlessScope(constants.ScopeNotifications).
+		WithParseErrorListOnError().
+		WithRateLimit(1, time.Second).
+		Execute(a.httpClient)
+	return err
+}
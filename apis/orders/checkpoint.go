@@ -0,0 +1,120 @@
+package orders
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the durable state of a Syncer.
+type Checkpoint struct {
+	// Watermark is the LastUpdatedTime up to which all orders have been handled.
+	Watermark time.Time `json:"watermark"`
+	// Seen maps the OrderID to the LastUpdatedTime of orders handled within the overlap window.
+	// It is used to deduplicate orders which are returned again by overlapping searches.
+	Seen map[string]time.Time `json:"seen,omitempty"`
+}
+
+// CheckpointStore persists the Checkpoint of a Syncer.
+type CheckpointStore interface {
+	// Load returns the last saved checkpoint or nil if none was saved yet.
+	Load() (*Checkpoint, error)
+	// Save stores the checkpoint, replacing the previous one.
+	Save(checkpoint Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory. It is lost when the process exits.
+type MemoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+// NewMemoryCheckpointStore returns an empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{}
+}
+
+func (s *MemoryCheckpointStore) Load() (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	c := copyCheckpoint(*s.checkpoint)
+	return &c, nil
+}
+
+func (s *MemoryCheckpointStore) Save(checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := copyCheckpoint(checkpoint)
+	s.checkpoint = &c
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint as JSON file.
+type FileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore returns a FileCheckpointStore writing to the given path.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load() (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{}
+	if err = json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint to a temporary file first and renames it afterwards,
+// so a crash never leaves a partially written checkpoint behind.
+func (s *FileCheckpointStore) Save(checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func copyCheckpoint(c Checkpoint) Checkpoint {
+	seen := make(map[string]time.Time, len(c.Seen))
+	for k, v := range c.Seen {
+		seen[k] = v
+	}
+	c.Seen = seen
+	return c
+}
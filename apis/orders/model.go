@@ -1,8 +1,12 @@
 package orders
 
-import "time"
+import (
+	"time"
 
-// IncludedData represents the datasets that can be included in a GetOrder response.
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+)
+
+// IncludedData represents the datasets that can be included in a GetOrder or SearchOrders response.
 type IncludedData string
 
 const (
@@ -146,8 +150,8 @@ type OrderProceeds struct {
 
 // Money represents a monetary amount with currency.
 type Money struct {
-	Amount       string `json:"amount"`
-	CurrencyCode string `json:"currencyCode"`
+	Amount       apis.Amount `json:"amount"`
+	CurrencyCode string      `json:"currencyCode"`
 }
 
 // OrderFulfillment contains information about order processing and shipping.
@@ -0,0 +1,34 @@
+package orders
+
+import "github.com/fond-of-vertigo/amazon-sp-api/apis"
+
+// Add returns m + other. It fails with apis.ErrCurrencyMismatch if the currencies differ.
+func (m Money) Add(other Money) (Money, error) {
+	if err := apis.CheckSameCurrency(m.CurrencyCode, other.CurrencyCode); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Add(other.Amount), CurrencyCode: m.CurrencyCode}, nil
+}
+
+// Sub returns m - other. It fails with apis.ErrCurrencyMismatch if the currencies differ.
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(other.Neg())
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), CurrencyCode: m.CurrencyCode}
+}
+
+// Cmp compares the amounts like apis.Amount.Cmp. It fails with apis.ErrCurrencyMismatch if the currencies differ.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := apis.CheckSameCurrency(m.CurrencyCode, other.CurrencyCode); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(other.Amount), nil
+}
+
+// Round rounds the amount to the minor units of its currency.
+func (m Money) Round() Money {
+	return Money{Amount: m.Amount.RoundToCurrency(m.CurrencyCode), CurrencyCode: m.CurrencyCode}
+}
@@ -0,0 +1,28 @@
+package orders
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "String as sent by the API", input: `{"amount":"19.99","currencyCode":"EUR"}`},
+		{name: "Number", input: `{"amount":19.99,"currencyCode":"EUR"}`},
+		{name: "Trailing zero", input: `{"amount":"4.90","currencyCode":"EUR"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			assert.NoError(t, json.Unmarshal([]byte(tt.input), &m))
+			got, err := json.Marshal(m)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.input, string(got))
+		})
+	}
+}
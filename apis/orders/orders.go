@@ -25,11 +25,16 @@ func NewAPI(httpClient *httpx.Client) *API {
 // GetOrder returns the order that you specify.
 // includedData is optional and specifies which datasets to include in the response.
 // A restrictedDataToken is optional and may be passed to receive Personally Identifiable Information (PII).
+// If it is nil and automatic RDT acquisition is enabled, an RDT for the PII of the includedData is fetched.
 func (a *API) GetOrder(orderID string, includedData []IncludedData, restrictedDataToken *string) (*apis.CallResponse[GetOrderResponse], error) {
-	call := apis.NewCall[GetOrderResponse](http.MethodGet, pathPrefix+"/orders/"+orderID).
+	call := apis.NewCall[GetOrderResponse](http.MethodGet, OrderPath(orderID)).
 		WithRateLimit(0.0167, time.Second).
 		WithRestrictedDataToken(restrictedDataToken)
 
+	if dataElements := RestrictedDataElements(includedData); len(dataElements) > 0 {
+		call = call.WithRestrictedResource(dataElements...)
+	}
+
 	if len(includedData) > 0 {
 		vals := make([]string, len(includedData))
 		for i, d := range includedData {
@@ -42,3 +47,41 @@ func (a *API) GetOrder(orderID string, includedData []IncludedData, restrictedDa
 
 	return call.Execute(a.httpClient)
 }
+
+// SearchOrders returns orders that match the filter that you specify.
+// Either CreatedAfter or LastUpdatedAfter is required, unless a PaginationToken is passed.
+// A restrictedDataToken is optional and may be passed to receive Personally Identifiable Information (PII).
+// If it is nil and automatic RDT acquisition is enabled, an RDT for the PII of the IncludedData is fetched.
+// Use SearchOrdersFilter.NextPage for the following pages, so that they are requested with an RDT, too.
+func (a *API) SearchOrders(filter *SearchOrdersFilter, restrictedDataToken *string) (*apis.CallResponse[SearchOrdersResponse], error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+
+	call := apis.NewCall[SearchOrdersResponse](http.MethodGet, pathPrefix+"/orders").
+		WithQueryParams(filter.GetQuery()).
+		WithRateLimit(0.0167, time.Second).
+		WithRestrictedDataToken(restrictedDataToken).
+		WithParseErrorListOnError()
+
+	if dataElements := RestrictedDataElements(filter.IncludedData); len(dataElements) > 0 {
+		call = call.WithRestrictedResource(dataElements...)
+	}
+
+	return call.Execute(a.httpClient)
+}
+
+// RestrictedDataElements returns the data elements of a Restricted Data Token (RDT)
+// which are required to receive the PII of the includedData.
+func RestrictedDataElements(includedData []IncludedData) []string {
+	var dataElements []string
+	for _, d := range includedData {
+		switch d {
+		case IncludedDataBuyer:
+			dataElements = append(dataElements, "buyerInfo")
+		case IncludedDataRecipient:
+			dataElements = append(dataElements, "shippingAddress")
+		}
+	}
+	return dataElements
+}
@@ -0,0 +1,48 @@
+package orders_test
+
+import (
+	"testing"
+	"time"
+
+	sp_api "github.com/fond-of-vertigo/amazon-sp-api"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/spapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_SearchOrders_NextPageUsesRDT(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := spapitest.NewServer(t)
+	s.AddOrders(
+		orders.Order{OrderID: "A", CreatedTime: created, LastUpdatedTime: created},
+		orders.Order{OrderID: "B", CreatedTime: created, LastUpdatedTime: created},
+	)
+	client := s.NewClient(sp_api.Config{AutoRestrictedDataToken: true})
+
+	maxResults := 1
+	filter := &orders.SearchOrdersFilter{
+		CreatedAfter:      &apis.JsonTimeISO8601{Time: created.Add(-time.Hour)},
+		IncludedData:      []orders.IncludedData{orders.IncludedDataBuyer},
+		MaxResultsPerPage: &maxResults,
+	}
+	first, err := client.OrdersAPI.SearchOrders(filter, nil)
+	require.NoError(t, err)
+	nextToken := first.ResponseBody.GetNextToken()
+	require.NotNil(t, nextToken)
+
+	second, err := client.OrdersAPI.SearchOrders(filter.NextPage(nextToken), nil)
+	require.NoError(t, err)
+	require.Len(t, second.ResponseBody.Orders, 1)
+	assert.Equal(t, "B", second.ResponseBody.Orders[0].OrderID)
+
+	requests := s.Requests(spapitest.OperationSearchOrders)
+	require.Len(t, requests, 2)
+	for _, request := range requests {
+		assert.Equal(t, spapitest.RestrictedDataToken, request.Header.Get(constants.AccessTokenHeader))
+	}
+	assert.Equal(t, *nextToken, requests[1].Query.Get("paginationToken"))
+	assert.Empty(t, requests[1].Query.Get("includedData"))
+}
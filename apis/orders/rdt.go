@@ -0,0 +1,34 @@
+package orders
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/tokens"
+)
+
+// OrderPath returns the path of the getOrder operation for the order,
+// which is the restricted resource of a Restricted Data Token (RDT) for the order.
+func OrderPath(orderID string) string {
+	return pathPrefix + "/orders/" + orderID
+}
+
+// CreateRestrictedDataTokens issues RDTs for the getOrder operation of many orders at once,
+// using one RDT per tokens.MaxRestrictedResources orders instead of one RDT per order.
+// The data elements are derived from includedData, which must contain BUYER or RECIPIENT.
+// Pass the RDT of an order to GetOrder with tokens.RestrictedDataTokens.Get(orderID).
+func CreateRestrictedDataTokens(tokenAPI *tokens.API, orderIDs []string, includedData []IncludedData) (tokens.RestrictedDataTokens, error) {
+	dataElements := RestrictedDataElements(includedData)
+	if len(dataElements) == 0 {
+		return nil, errors.New("includedData does not contain restricted data")
+	}
+
+	pathsByOrderID := make(map[string]string, len(orderIDs))
+	for _, orderID := range orderIDs {
+		if orderID == "" {
+			return nil, errors.New("orderID must not be empty")
+		}
+		pathsByOrderID[orderID] = OrderPath(orderID)
+	}
+	return tokenAPI.CreateRestrictedDataTokens(http.MethodGet, pathsByOrderID, dataElements)
+}
@@ -0,0 +1,147 @@
+package orders
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/internal/utils"
+)
+
+// FulfillmentStatus is the status of the order fulfillment.
+type FulfillmentStatus string
+
+const (
+	FulfillmentStatusPendingAvailability FulfillmentStatus = "PENDING_AVAILABILITY"
+	FulfillmentStatusPending             FulfillmentStatus = "PENDING"
+	FulfillmentStatusUnshipped           FulfillmentStatus = "UNSHIPPED"
+	FulfillmentStatusPartiallyShipped    FulfillmentStatus = "PARTIALLY_SHIPPED"
+	FulfillmentStatusShipped             FulfillmentStatus = "SHIPPED"
+	FulfillmentStatusCancelled           FulfillmentStatus = "CANCELLED"
+	FulfillmentStatusUnfulfillable       FulfillmentStatus = "UNFULFILLABLE"
+)
+
+// FulfilledBy is the party responsible for the fulfillment of an order.
+type FulfilledBy string
+
+const (
+	FulfilledByAmazon   FulfilledBy = "AMAZON"
+	FulfilledByMerchant FulfilledBy = "MERCHANT"
+)
+
+// SearchOrdersFilter is used to filter orders in the SearchOrders call.
+type SearchOrdersFilter struct {
+	// Orders created after this time are returned. Either CreatedAfter or LastUpdatedAfter is required.
+	CreatedAfter *apis.JsonTimeISO8601
+	// Orders created before this time are returned. Must be at least two minutes before the time of the request.
+	CreatedBefore *apis.JsonTimeISO8601
+	// Orders updated after this time are returned. Either CreatedAfter or LastUpdatedAfter is required.
+	LastUpdatedAfter *apis.JsonTimeISO8601
+	// Orders updated before this time are returned. Must be at least two minutes before the time of the request.
+	LastUpdatedBefore *apis.JsonTimeISO8601
+	// Only orders with one of these fulfillment statuses are returned.
+	FulfillmentStatuses []FulfillmentStatus
+	// Only orders fulfilled by this channel are returned.
+	FulfilledBy []FulfilledBy
+	// Only orders placed in one of these marketplaces are returned. Maximum 50.
+	MarketplaceIDs []constants.MarketplaceID
+	// The datasets to include for each order. It is also used to request the RDT of paginated calls,
+	// so it must be passed with the PaginationToken, see NextPage.
+	IncludedData []IncludedData
+	// The maximum number of orders per page. Minimum 1, maximum 100.
+	MaxResultsPerPage *int
+	// The token returned by a previous call to fetch the next page. All other filters are not sent if set.
+	PaginationToken *string
+}
+
+// NextPage returns the filter for the page of nextToken. The IncludedData is carried over, so
+// that the PII of the next page is requested with an RDT as well.
+func (f *SearchOrdersFilter) NextPage(nextToken *string) *SearchOrdersFilter {
+	return &SearchOrdersFilter{
+		IncludedData:    f.IncludedData,
+		PaginationToken: nextToken,
+	}
+}
+
+// GetMarketplaceIDs implements apis.MarketplaceScoped.
+func (f *SearchOrdersFilter) GetMarketplaceIDs() []constants.MarketplaceID {
+	return f.MarketplaceIDs
+}
+
+// GetQuery returns the query parameters for SearchOrdersFilter.
+func (f *SearchOrdersFilter) GetQuery() url.Values {
+	q := url.Values{}
+	if f.PaginationToken != nil {
+		q.Add("paginationToken", *f.PaginationToken)
+		return q
+	}
+	if f.CreatedAfter != nil {
+		q.Add("createdAfter", f.CreatedAfter.String())
+	}
+	if f.CreatedBefore != nil {
+		q.Add("createdBefore", f.CreatedBefore.String())
+	}
+	if f.LastUpdatedAfter != nil {
+		q.Add("lastUpdatedAfter", f.LastUpdatedAfter.String())
+	}
+	if f.LastUpdatedBefore != nil {
+		q.Add("lastUpdatedBefore", f.LastUpdatedBefore.String())
+	}
+	utils.AddToQueryIfSet(q, "fulfillmentStatuses", utils.MapToCommaString(f.FulfillmentStatuses))
+	utils.AddToQueryIfSet(q, "fulfilledBy", utils.MapToCommaString(f.FulfilledBy))
+	utils.AddToQueryIfSet(q, "marketplaceIds", utils.MapToCommaString(f.MarketplaceIDs))
+	utils.AddToQueryIfSet(q, "includedData", utils.MapToCommaString(f.IncludedData))
+	if f.MaxResultsPerPage != nil {
+		q.Add("maxResultsPerPage", strconv.Itoa(*f.MaxResultsPerPage))
+	}
+
+	return q
+}
+
+func (f *SearchOrdersFilter) validate() error {
+	if f.MaxResultsPerPage != nil && (*f.MaxResultsPerPage < 1 || *f.MaxResultsPerPage > 100) {
+		return errors.New("maxResultsPerPage must be between 1 and 100")
+	}
+	if f.PaginationToken != nil {
+		return nil
+	}
+	if f.CreatedAfter == nil && f.LastUpdatedAfter == nil {
+		return errors.New("either createdAfter or lastUpdatedAfter is required")
+	}
+	if f.CreatedAfter != nil && f.LastUpdatedAfter != nil {
+		return errors.New("createdAfter and lastUpdatedAfter cannot be combined")
+	}
+	if len(f.MarketplaceIDs) > 50 {
+		return errors.New("marketplaceIds cannot contain more than 50 marketplaces")
+	}
+	return nil
+}
+
+// SearchOrdersResponse is the response for the SearchOrders operation.
+type SearchOrdersResponse struct {
+	// The orders of the current page.
+	Orders []Order `json:"orders"`
+	// Contains the token for the next page, if any.
+	Pagination *Pagination `json:"pagination,omitempty"`
+	// Only orders updated before this time are contained. Use it as lower bound of the next search.
+	LastUpdatedBefore *time.Time `json:"lastUpdatedBefore,omitempty"`
+	// Only orders created before this time are contained. Use it as lower bound of the next search.
+	CreatedBefore *time.Time `json:"createdBefore,omitempty"`
+}
+
+// Pagination contains the token to fetch the next page of a search.
+type Pagination struct {
+	// Pass this token as PaginationToken to get the next page. Empty on the last page.
+	NextToken *string `json:"nextToken,omitempty"`
+}
+
+// GetNextToken returns the token of the next page or nil if this is the last page.
+func (r *SearchOrdersResponse) GetNextToken() *string {
+	if r.Pagination == nil || r.Pagination.NextToken == nil || *r.Pagination.NextToken == "" {
+		return nil
+	}
+	return r.Pagination.NextToken
+}
@@ -0,0 +1,109 @@
+package orders
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchOrdersFilter_GetQuery(t *testing.T) {
+	createdAfter := &apis.JsonTimeISO8601{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	lastUpdatedBefore := &apis.JsonTimeISO8601{Time: time.Date(2026, 1, 2, 12, 30, 0, 0, time.UTC)}
+	maxResults := 50
+	token := "next-page"
+	tests := []struct {
+		name   string
+		filter *SearchOrdersFilter
+		want   url.Values
+	}{
+		{
+			name:   "Times",
+			filter: &SearchOrdersFilter{CreatedAfter: createdAfter, LastUpdatedBefore: lastUpdatedBefore},
+			want: url.Values{
+				"createdAfter":      {"2026-01-01T00:00:00Z"},
+				"lastUpdatedBefore": {"2026-01-02T12:30:00Z"},
+			},
+		},
+		{
+			name: "Lists are comma separated",
+			filter: &SearchOrdersFilter{
+				CreatedAfter:        createdAfter,
+				FulfillmentStatuses: []FulfillmentStatus{FulfillmentStatusUnshipped, FulfillmentStatusPartiallyShipped},
+				FulfilledBy:         []FulfilledBy{FulfilledByMerchant},
+				MarketplaceIDs:      []constants.MarketplaceID{constants.Germany, constants.France},
+				IncludedData:        []IncludedData{IncludedDataBuyer, IncludedDataProceeds},
+				MaxResultsPerPage:   &maxResults,
+			},
+			want: url.Values{
+				"createdAfter":        {"2026-01-01T00:00:00Z"},
+				"fulfillmentStatuses": {"UNSHIPPED,PARTIALLY_SHIPPED"},
+				"fulfilledBy":         {"MERCHANT"},
+				"marketplaceIds":      {string(constants.Germany) + "," + string(constants.France)},
+				"includedData":        {"BUYER,PROCEEDS"},
+				"maxResultsPerPage":   {"50"},
+			},
+		},
+		{
+			name: "PaginationToken ignores all other filters",
+			filter: &SearchOrdersFilter{
+				CreatedAfter:      createdAfter,
+				MarketplaceIDs:    []constants.MarketplaceID{constants.Germany},
+				MaxResultsPerPage: &maxResults,
+				PaginationToken:   &token,
+			},
+			want: url.Values{"paginationToken": {"next-page"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.GetQuery())
+		})
+	}
+}
+
+func TestSearchOrdersFilter_validate(t *testing.T) {
+	after := &apis.JsonTimeISO8601{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	intPtr := func(i int) *int { return &i }
+	token := "next-page"
+	tooManyMarketplaces := make([]constants.MarketplaceID, 51)
+	for i := range tooManyMarketplaces {
+		tooManyMarketplaces[i] = constants.MarketplaceID("M" + strconv.Itoa(i))
+	}
+	tests := []struct {
+		name    string
+		filter  *SearchOrdersFilter
+		wantErr string
+	}{
+		{name: "CreatedAfter", filter: &SearchOrdersFilter{CreatedAfter: after}},
+		{name: "LastUpdatedAfter", filter: &SearchOrdersFilter{LastUpdatedAfter: after}},
+		{name: "Neither CreatedAfter nor LastUpdatedAfter", filter: &SearchOrdersFilter{}, wantErr: "either createdAfter or lastUpdatedAfter is required"},
+		{name: "Both CreatedAfter and LastUpdatedAfter", filter: &SearchOrdersFilter{CreatedAfter: after, LastUpdatedAfter: after}, wantErr: "cannot be combined"},
+		{name: "PaginationToken without times", filter: &SearchOrdersFilter{PaginationToken: &token}},
+		{name: "maxResultsPerPage 1", filter: &SearchOrdersFilter{CreatedAfter: after, MaxResultsPerPage: intPtr(1)}},
+		{name: "maxResultsPerPage 100", filter: &SearchOrdersFilter{CreatedAfter: after, MaxResultsPerPage: intPtr(100)}},
+		{name: "maxResultsPerPage 0", filter: &SearchOrdersFilter{CreatedAfter: after, MaxResultsPerPage: intPtr(0)}, wantErr: "maxResultsPerPage"},
+		{name: "maxResultsPerPage 101", filter: &SearchOrdersFilter{CreatedAfter: after, MaxResultsPerPage: intPtr(101)}, wantErr: "maxResultsPerPage"},
+		{name: "51 marketplaces", filter: &SearchOrdersFilter{CreatedAfter: after, MarketplaceIDs: tooManyMarketplaces}, wantErr: "more than 50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAPI_SearchOrders_ValidatesBeforeCalling(t *testing.T) {
+	api := &API{}
+	_, err := api.SearchOrders(&SearchOrdersFilter{}, nil)
+	assert.EqualError(t, err, "either createdAfter or lastUpdatedAfter is required")
+}
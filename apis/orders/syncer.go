@@ -0,0 +1,158 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// OrderHandler is called for every new or changed order. If it returns an error,
+// the sync is aborted and the order is emitted again by the next sync.
+type OrderHandler func(order Order) error
+
+// SyncerConfig configures a Syncer.
+type SyncerConfig struct {
+	// Store persists the watermark between syncs. Required.
+	Store CheckpointStore
+	// Handler is called for every new or changed order. Required.
+	Handler OrderHandler
+	// StartTime is used as watermark if the Store does not contain a checkpoint yet.
+	StartTime time.Time
+	// Overlap is the time window before the watermark which is searched again on every sync.
+	// Defaults to constants.DefaultOrderSyncOverlap.
+	Overlap time.Duration
+	// MarketplaceIDs, FulfillmentStatuses, FulfilledBy, IncludedData and MaxResultsPerPage are
+	// passed to SearchOrders and are optional.
+	MarketplaceIDs      []constants.MarketplaceID
+	FulfillmentStatuses []FulfillmentStatus
+	FulfilledBy         []FulfilledBy
+	IncludedData        []IncludedData
+	MaxResultsPerPage   *int
+}
+
+// Syncer emits all orders which were created or updated since the last sync.
+// It searches orders by LastUpdatedTime, starting an overlap window before the stored
+// watermark, and skips orders that were already emitted with the same LastUpdatedTime.
+// Orders are emitted at least once: the checkpoint is only advanced after the handler succeeded.
+type Syncer struct {
+	mu           sync.Mutex
+	config       SyncerConfig
+	searchOrders func(filter *SearchOrdersFilter) (*apis.CallResponse[SearchOrdersResponse], error)
+	now          func() time.Time
+}
+
+// NewSyncer returns a Syncer which searches orders with the given API.
+func NewSyncer(api *API, config SyncerConfig) (*Syncer, error) {
+	if config.Store == nil {
+		return nil, errors.New("checkpoint store is required")
+	}
+	if config.Handler == nil {
+		return nil, errors.New("order handler is required")
+	}
+	if config.Overlap <= 0 {
+		config.Overlap = constants.DefaultOrderSyncOverlap
+	}
+
+	return &Syncer{
+		config: config,
+		searchOrders: func(filter *SearchOrdersFilter) (*apis.CallResponse[SearchOrdersResponse], error) {
+			return api.SearchOrders(filter, nil)
+		},
+		now: time.Now,
+	}, nil
+}
+
+// Sync searches all orders updated since the stored watermark, passes new or changed
+// orders to the handler and advances the watermark. It returns the number of emitted orders.
+// Sync is meant to be called periodically, e.g. every few minutes.
+func (s *Syncer) Sync() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint, err := s.loadCheckpoint()
+	if err != nil {
+		return 0, err
+	}
+
+	from := checkpoint.Watermark.Add(-s.config.Overlap)
+	until := s.now().Add(-constants.MinBeforeFilterAge).Truncate(time.Second)
+	if !until.After(from) {
+		return 0, nil
+	}
+
+	filter := &SearchOrdersFilter{
+		LastUpdatedAfter:    &apis.JsonTimeISO8601{Time: from},
+		LastUpdatedBefore:   &apis.JsonTimeISO8601{Time: until},
+		MarketplaceIDs:      s.config.MarketplaceIDs,
+		FulfillmentStatuses: s.config.FulfillmentStatuses,
+		FulfilledBy:         s.config.FulfilledBy,
+		IncludedData:        s.config.IncludedData,
+		MaxResultsPerPage:   s.config.MaxResultsPerPage,
+	}
+
+	// the response tells up to which time the orders are contained, which is the next watermark
+	watermark := until
+	emitted := 0
+	for {
+		resp, err := s.searchOrders(filter)
+		if err != nil {
+			return emitted, err
+		}
+		if resp.ResponseBody == nil {
+			return emitted, errors.New("searchOrders returned an empty response")
+		}
+		if lastUpdatedBefore := resp.ResponseBody.LastUpdatedBefore; lastUpdatedBefore != nil {
+			watermark = *lastUpdatedBefore
+		}
+
+		for _, order := range resp.ResponseBody.Orders {
+			if seen, ok := checkpoint.Seen[order.OrderID]; ok && !order.LastUpdatedTime.After(seen) {
+				continue
+			}
+			if err = s.config.Handler(order); err != nil {
+				return emitted, fmt.Errorf("handling order %s failed: %w", order.OrderID, err)
+			}
+			checkpoint.Seen[order.OrderID] = order.LastUpdatedTime
+			emitted++
+		}
+
+		nextToken := resp.ResponseBody.GetNextToken()
+		if nextToken == nil {
+			break
+		}
+		// store the progress, so the handled orders are skipped if a later page fails
+		if err = s.config.Store.Save(*checkpoint); err != nil {
+			return emitted, err
+		}
+		filter = filter.NextPage(nextToken)
+	}
+
+	checkpoint.Watermark = watermark
+	for orderID, lastUpdated := range checkpoint.Seen {
+		if lastUpdated.Before(watermark.Add(-s.config.Overlap)) {
+			delete(checkpoint.Seen, orderID)
+		}
+	}
+	return emitted, s.config.Store.Save(*checkpoint)
+}
+
+func (s *Syncer) loadCheckpoint() (*Checkpoint, error) {
+	checkpoint, err := s.config.Store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil {
+		if s.config.StartTime.IsZero() {
+			return nil, errors.New("no checkpoint stored and no start time configured")
+		}
+		checkpoint = &Checkpoint{Watermark: s.config.StartTime}
+	}
+	if checkpoint.Seen == nil {
+		checkpoint.Seen = map[string]time.Time{}
+	}
+	return checkpoint, nil
+}
@@ -0,0 +1,142 @@
+package orders
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockOrderSearch struct {
+	pages             [][]Order
+	filters           []*SearchOrdersFilter
+	lastUpdatedBefore *time.Time
+}
+
+func (m *mockOrderSearch) search(filter *SearchOrdersFilter) (*apis.CallResponse[SearchOrdersResponse], error) {
+	m.filters = append(m.filters, filter)
+	page := 0
+	if filter.PaginationToken != nil {
+		page = int((*filter.PaginationToken)[0] - '0')
+	}
+	resp := &SearchOrdersResponse{Orders: m.pages[page], LastUpdatedBefore: m.lastUpdatedBefore}
+	if page+1 < len(m.pages) {
+		next := string(rune('0' + page + 1))
+		resp.Pagination = &Pagination{NextToken: &next}
+	}
+	return &apis.CallResponse[SearchOrdersResponse]{Status: 200, ResponseBody: resp}, nil
+}
+
+func newTestSyncer(t *testing.T, store CheckpointStore, search *mockOrderSearch, handler OrderHandler, now time.Time) *Syncer {
+	s, err := NewSyncer(nil, SyncerConfig{
+		Store:     store,
+		Handler:   handler,
+		StartTime: now.Add(-time.Hour),
+		Overlap:   10 * time.Minute,
+	})
+	assert.NoError(t, err)
+	s.searchOrders = search.search
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	updated := now.Add(-5 * time.Minute)
+	search := &mockOrderSearch{pages: [][]Order{
+		{{OrderID: "A", LastUpdatedTime: updated}, {OrderID: "B", LastUpdatedTime: updated}},
+		{{OrderID: "C", LastUpdatedTime: updated}},
+	}}
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "orders.json"))
+
+	var handled []string
+	handler := func(order Order) error {
+		handled = append(handled, order.OrderID)
+		return nil
+	}
+
+	// first sync emits all orders and advances the watermark
+	s := newTestSyncer(t, store, search, handler, now)
+	n, err := s.Sync()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"A", "B", "C"}, handled)
+	assert.Equal(t, now.Add(-time.Hour-10*time.Minute), search.filters[0].LastUpdatedAfter.Time)
+	assert.Equal(t, now.Add(-2*time.Minute), search.filters[0].LastUpdatedBefore.Time)
+
+	checkpoint, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-2*time.Minute), checkpoint.Watermark)
+
+	// second sync overlaps with the first one and only emits the changed order
+	later := now.Add(5 * time.Minute)
+	search.pages = [][]Order{{
+		{OrderID: "A", LastUpdatedTime: updated},
+		{OrderID: "B", LastUpdatedTime: later.Add(-3 * time.Minute)},
+	}}
+	search.filters = nil
+	handled = nil
+	s = newTestSyncer(t, store, search, handler, later)
+	n, err = s.Sync()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"B"}, handled)
+	assert.Equal(t, now.Add(-12*time.Minute), search.filters[0].LastUpdatedAfter.Time)
+}
+
+func TestSyncer_SyncUsesResponseWatermark(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastUpdatedBefore := now.Add(-10 * time.Minute)
+	search := &mockOrderSearch{
+		pages:             [][]Order{{{OrderID: "A", LastUpdatedTime: now.Add(-20 * time.Minute)}}, {}},
+		lastUpdatedBefore: &lastUpdatedBefore,
+	}
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "orders.json"))
+
+	s := newTestSyncer(t, store, search, func(Order) error { return nil }, now)
+	_, err := s.Sync()
+	assert.NoError(t, err)
+
+	checkpoint, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, lastUpdatedBefore, checkpoint.Watermark)
+}
+
+func TestSyncer_SyncHandlerError(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	updated := now.Add(-5 * time.Minute)
+	search := &mockOrderSearch{pages: [][]Order{
+		{{OrderID: "A", LastUpdatedTime: updated}},
+		{{OrderID: "B", LastUpdatedTime: updated}},
+	}}
+	store := NewMemoryCheckpointStore()
+
+	var handled []string
+	failFor := "B"
+	handler := func(order Order) error {
+		if order.OrderID == failFor {
+			return errors.New("boom")
+		}
+		handled = append(handled, order.OrderID)
+		return nil
+	}
+
+	s := newTestSyncer(t, store, search, handler, now)
+	n, err := s.Sync()
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+
+	checkpoint, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), checkpoint.Watermark, "watermark must not advance on failure")
+
+	// the retry emits the failed order again, but skips the already handled one
+	failFor = ""
+	n, err = s.Sync()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"A", "B"}, handled)
+}
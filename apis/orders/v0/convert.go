@@ -0,0 +1,226 @@
+package v0
+
+import (
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+)
+
+var fulfillmentStatusByOrderStatus = map[OrderStatus]orders.FulfillmentStatus{
+	OrderStatusPendingAvailability: orders.FulfillmentStatusPendingAvailability,
+	OrderStatusPending:             orders.FulfillmentStatusPending,
+	OrderStatusUnshipped:           orders.FulfillmentStatusUnshipped,
+	OrderStatusInvoiceUnconfirmed:  orders.FulfillmentStatusUnshipped,
+	OrderStatusPartiallyShipped:    orders.FulfillmentStatusPartiallyShipped,
+	OrderStatusShipped:             orders.FulfillmentStatusShipped,
+	OrderStatusCanceled:            orders.FulfillmentStatusCancelled,
+	OrderStatusUnfulfillable:       orders.FulfillmentStatusUnfulfillable,
+}
+
+var fulfilledByByFulfillmentChannel = map[FulfillmentChannel]orders.FulfilledBy{
+	FulfillmentChannelAFN: orders.FulfilledByAmazon,
+	FulfillmentChannelMFN: orders.FulfilledByMerchant,
+}
+
+// ToOrder converts a v0 order and its items into the orders.Order of the 2026-01-01 API,
+// so code migrating to the new API can work on a single model. items are optional.
+// Fields which do not exist in the v0 API stay empty.
+func ToOrder(order Order, items []OrderItem) orders.Order {
+	o := orders.Order{
+		OrderID:         order.AmazonOrderId,
+		CreatedTime:     order.PurchaseDate,
+		LastUpdatedTime: order.LastUpdateDate,
+		SalesChannel: orders.SalesChannel{
+			ChannelName:     deref(order.SalesChannel),
+			MarketplaceID:   deref(order.MarketplaceId),
+			MarketplaceName: deref(order.SalesChannel),
+		},
+		Fulfillment: &orders.OrderFulfillment{
+			FulfillmentStatus:       string(fulfillmentStatusByOrderStatus[order.OrderStatus]),
+			FulfillmentServiceLevel: deref(order.ShipServiceLevel),
+			ShipByWindow:            toDateTimeRange(order.EarliestShipDate, order.LatestShipDate),
+			DeliverByWindow:         toDateTimeRange(order.EarliestDeliveryDate, order.LatestDeliveryDate),
+		},
+		OrderItems: make([]orders.OrderItem, len(items)),
+	}
+
+	if order.SellerOrderId != nil {
+		o.OrderAliases = []orders.Alias{{AliasID: *order.SellerOrderId, AliasType: "SELLER_ORDER_ID"}}
+	}
+	if order.ReplacedOrderId != nil && *order.ReplacedOrderId != "" {
+		o.AssociatedOrders = []orders.AssociatedOrder{{OrderID: *order.ReplacedOrderId, AssociationType: "REPLACEMENT_ORIGINAL_ID"}}
+	}
+	if order.FulfillmentChannel != nil {
+		o.Fulfillment.FulfilledBy = string(fulfilledByByFulfillmentChannel[*order.FulfillmentChannel])
+	}
+	if isTrue(order.IsPrime) {
+		o.Programs = append(o.Programs, "PRIME")
+	}
+	if isTrue(order.IsBusinessOrder) {
+		o.Programs = append(o.Programs, "AMAZON_BUSINESS")
+	}
+	if isTrue(order.IsPremiumOrder) {
+		o.Programs = append(o.Programs, "PREMIUM")
+	}
+	if order.OrderTotal != nil {
+		o.Proceeds = &orders.OrderProceeds{GrandTotal: toMoney(order.OrderTotal)}
+	}
+	if order.BuyerInfo != nil {
+		o.Buyer = &orders.Buyer{
+			BuyerName:                deref(order.BuyerInfo.BuyerName),
+			BuyerEmail:               deref(order.BuyerInfo.BuyerEmail),
+			BuyerPurchaseOrderNumber: deref(order.BuyerInfo.PurchaseOrderNumber),
+		}
+		if order.BuyerInfo.BuyerTaxInfo != nil {
+			o.Buyer.BuyerCompanyName = deref(order.BuyerInfo.BuyerTaxInfo.CompanyLegalName)
+		}
+	}
+	if order.ShippingAddress != nil {
+		o.Recipient = &orders.Recipient{DeliveryAddress: ToCustomerAddress(*order.ShippingAddress)}
+	}
+
+	for i, item := range items {
+		o.OrderItems[i] = ToOrderItem(item)
+	}
+	return o
+}
+
+// ToCustomerAddress converts a v0 address into the orders.CustomerAddress of the 2026-01-01 API.
+func ToCustomerAddress(address Address) *orders.CustomerAddress {
+	districtOrCounty := deref(address.District)
+	if districtOrCounty == "" {
+		districtOrCounty = deref(address.County)
+	}
+	return &orders.CustomerAddress{
+		Name:             address.Name,
+		CompanyName:      deref(address.CompanyName),
+		AddressLine1:     deref(address.AddressLine1),
+		AddressLine2:     deref(address.AddressLine2),
+		AddressLine3:     deref(address.AddressLine3),
+		City:             deref(address.City),
+		DistrictOrCounty: districtOrCounty,
+		StateOrRegion:    deref(address.StateOrRegion),
+		Municipality:     deref(address.Municipality),
+		PostalCode:       deref(address.PostalCode),
+		CountryCode:      deref(address.CountryCode),
+		Phone:            deref(address.Phone),
+		AddressType:      deref(address.AddressType),
+	}
+}
+
+// ToOrderItem converts a v0 order item into the orders.OrderItem of the 2026-01-01 API.
+// The v0 prices are mapped to proceeds breakdowns, as v0 does not provide unit prices.
+func ToOrderItem(item OrderItem) orders.OrderItem {
+	i := orders.OrderItem{
+		OrderItemID:     item.OrderItemId,
+		QuantityOrdered: item.QuantityOrdered,
+		Product: orders.ItemProduct{
+			ASIN:          item.ASIN,
+			Title:         deref(item.Title),
+			SellerSKU:     deref(item.SellerSKU),
+			SerialNumbers: item.SerialNumbers,
+		},
+		Fulfillment: &orders.ItemFulfillment{
+			QuantityFulfilled:   deref(item.QuantityShipped),
+			QuantityUnfulfilled: item.QuantityOrdered - deref(item.QuantityShipped),
+		},
+	}
+
+	if item.ConditionId != nil {
+		i.Product.Condition = &orders.ItemCondition{
+			ConditionType:    *item.ConditionId,
+			ConditionSubtype: deref(item.ConditionSubtypeId),
+			ConditionNote:    deref(item.ConditionNote),
+		}
+	}
+
+	breakdowns := []priceBreakdown{
+		{"ITEM", item.ItemPrice},
+		{"ITEM_TAX", item.ItemTax},
+		{"SHIPPING", item.ShippingPrice},
+		{"SHIPPING_TAX", item.ShippingTax},
+		{"SHIPPING_DISCOUNT", item.ShippingDiscount},
+		{"PROMOTION_DISCOUNT", item.PromotionDiscount},
+	}
+	if item.BuyerInfo != nil {
+		breakdowns = append(breakdowns,
+			priceBreakdown{"GIFT_WRAP", item.BuyerInfo.GiftWrapPrice},
+			priceBreakdown{"GIFT_WRAP_TAX", item.BuyerInfo.GiftWrapTax},
+		)
+	}
+	for _, b := range breakdowns {
+		if b.money == nil {
+			continue
+		}
+		if i.Proceeds == nil {
+			i.Proceeds = &orders.ItemProceeds{}
+		}
+		i.Proceeds.Breakdowns = append(i.Proceeds.Breakdowns, orders.ItemProceedsBreakdown{
+			Type:     b.breakdownType,
+			Subtotal: toMoney(b.money),
+		})
+	}
+
+	if len(item.PromotionIds) > 0 {
+		i.Promotion = &orders.ItemPromotion{}
+		for _, id := range item.PromotionIds {
+			i.Promotion.Breakdowns = append(i.Promotion.Breakdowns, orders.ItemPromotionBreakdown{PromotionID: id})
+		}
+	}
+
+	if item.BuyerRequestedCancel != nil && deref(item.BuyerRequestedCancel.IsBuyerRequestedCancel) == "true" {
+		i.Cancellation = &orders.ItemCancellation{
+			CancellationRequest: &orders.ItemCancellationRequest{
+				Requester:    "BUYER",
+				CancelReason: deref(item.BuyerRequestedCancel.BuyerCancelReason),
+			},
+		}
+	}
+
+	if item.BuyerInfo != nil && (item.BuyerInfo.GiftMessageText != nil || item.BuyerInfo.GiftWrapLevel != nil) {
+		i.Fulfillment.Packing = &orders.ItemPacking{
+			GiftOption: &orders.GiftOption{
+				GiftMessage:   deref(item.BuyerInfo.GiftMessageText),
+				GiftWrapLevel: deref(item.BuyerInfo.GiftWrapLevel),
+			},
+		}
+	}
+
+	if item.IossNumber != nil {
+		i.Fulfillment.Shipping = &orders.ItemShipping{
+			InternationalShipping: &orders.ItemInternationalShipping{IOSSNumber: *item.IossNumber},
+		}
+	}
+	return i
+}
+
+type priceBreakdown struct {
+	breakdownType string
+	money         *Money
+}
+
+func toMoney(m *Money) *orders.Money {
+	return &orders.Money{
+		Amount:       deref(m.Amount),
+		CurrencyCode: deref(m.CurrencyCode),
+	}
+}
+
+func toDateTimeRange(earliest, latest *time.Time) *orders.DateTimeRange {
+	if earliest == nil && latest == nil {
+		return nil
+	}
+	return &orders.DateTimeRange{EarliestDateTime: earliest, LatestDateTime: latest}
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
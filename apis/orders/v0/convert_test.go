@@ -0,0 +1,88 @@
+package v0
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToOrder(t *testing.T) {
+	orderJSON := `{
+		"AmazonOrderId": "902-1845936-5435065",
+		"SellerOrderId": "SO-4711",
+		"PurchaseDate": "2026-01-12T19:42:04Z",
+		"LastUpdateDate": "2026-01-13T07:10:00Z",
+		"OrderStatus": "Unshipped",
+		"FulfillmentChannel": "MFN",
+		"SalesChannel": "Amazon.de",
+		"ShipServiceLevel": "Std DE Dom",
+		"OrderTotal": {"CurrencyCode": "EUR", "Amount": "24.89"},
+		"MarketplaceId": "A1PA6795UKMFR9",
+		"LatestShipDate": "2026-01-14T22:59:59Z",
+		"IsPrime": true,
+		"ShippingAddress": {"Name": "Max Mustermann", "City": "Köln", "County": "NRW", "CountryCode": "DE"}
+	}`
+	itemJSON := `{
+		"ASIN": "B00551Q3CS",
+		"SellerSKU": "SKU-1",
+		"OrderItemId": "68828574383266",
+		"QuantityOrdered": 2,
+		"QuantityShipped": 0,
+		"ItemPrice": {"CurrencyCode": "EUR", "Amount": "19.99"},
+		"ShippingPrice": {"CurrencyCode": "EUR", "Amount": "4.90"},
+		"BuyerRequestedCancel": {"IsBuyerRequestedCancel": "true", "BuyerCancelReason": "Found cheaper"}
+	}`
+
+	var order Order
+	var item OrderItem
+	assert.NoError(t, json.Unmarshal([]byte(orderJSON), &order))
+	assert.NoError(t, json.Unmarshal([]byte(itemJSON), &item))
+
+	got := ToOrder(order, []OrderItem{item})
+
+	assert.Equal(t, "902-1845936-5435065", got.OrderID)
+	assert.Equal(t, []orders.Alias{{AliasID: "SO-4711", AliasType: "SELLER_ORDER_ID"}}, got.OrderAliases)
+	assert.Equal(t, order.PurchaseDate, got.CreatedTime)
+	assert.Equal(t, "A1PA6795UKMFR9", got.SalesChannel.MarketplaceID)
+	assert.Equal(t, string(orders.FulfillmentStatusUnshipped), got.Fulfillment.FulfillmentStatus)
+	assert.Equal(t, string(orders.FulfilledByMerchant), got.Fulfillment.FulfilledBy)
+	assert.Equal(t, order.LatestShipDate, got.Fulfillment.ShipByWindow.LatestDateTime)
+	assert.Equal(t, []string{"PRIME"}, got.Programs)
+	assert.Equal(t, &orders.Money{Amount: apis.MustParseAmount("24.89").Quoted(), CurrencyCode: "EUR"}, got.Proceeds.GrandTotal)
+	assert.Equal(t, "NRW", got.Recipient.DeliveryAddress.DistrictOrCounty)
+
+	if assert.Len(t, got.OrderItems, 1) {
+		gotItem := got.OrderItems[0]
+		assert.Equal(t, "68828574383266", gotItem.OrderItemID)
+		assert.Equal(t, "SKU-1", gotItem.Product.SellerSKU)
+		assert.Equal(t, 2, gotItem.Fulfillment.QuantityUnfulfilled)
+		assert.Equal(t, []orders.ItemProceedsBreakdown{
+			{Type: "ITEM", Subtotal: &orders.Money{Amount: apis.MustParseAmount("19.99").Quoted(), CurrencyCode: "EUR"}},
+			{Type: "SHIPPING", Subtotal: &orders.Money{Amount: apis.MustParseAmount("4.90").Quoted(), CurrencyCode: "EUR"}},
+		}, gotItem.Proceeds.Breakdowns)
+		assert.Equal(t, "Found cheaper", gotItem.Cancellation.CancellationRequest.CancelReason)
+	}
+}
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "String as sent by the API", input: `{"CurrencyCode":"EUR","Amount":"19.99"}`},
+		{name: "Number", input: `{"CurrencyCode":"EUR","Amount":19.99}`},
+		{name: "Trailing zero", input: `{"CurrencyCode":"EUR","Amount":"4.90"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			assert.NoError(t, json.Unmarshal([]byte(tt.input), &m))
+			got, err := json.Marshal(m)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.input, string(got))
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package v0
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/internal/utils"
+)
+
+// OrderStatus The current order status.
+type OrderStatus string
+
+const (
+	OrderStatusPendingAvailability OrderStatus = "PendingAvailability"
+	OrderStatusPending             OrderStatus = "Pending"
+	OrderStatusUnshipped           OrderStatus = "Unshipped"
+	OrderStatusPartiallyShipped    OrderStatus = "PartiallyShipped"
+	OrderStatusShipped             OrderStatus = "Shipped"
+	OrderStatusCanceled            OrderStatus = "Canceled"
+	OrderStatusUnfulfillable       OrderStatus = "Unfulfillable"
+	OrderStatusInvoiceUnconfirmed  OrderStatus = "InvoiceUnconfirmed"
+)
+
+// FulfillmentChannel Whether the order was fulfilled by Amazon (AFN) or by the seller (MFN).
+type FulfillmentChannel string
+
+const (
+	FulfillmentChannelAFN FulfillmentChannel = "AFN"
+	FulfillmentChannelMFN FulfillmentChannel = "MFN"
+)
+
+// GetOrdersFilter is used to filter orders in the GetOrders call.
+type GetOrdersFilter struct {
+	// A date used for selecting orders created after (or at) a specified time.
+	// Either CreatedAfter or LastUpdatedAfter is required. Both cannot be empty.
+	CreatedAfter *apis.JsonTimeISO8601
+	// A date used for selecting orders created before (or at) a specified time.
+	CreatedBefore *apis.JsonTimeISO8601
+	// A date used for selecting orders that were last updated after (or at) a specified time.
+	LastUpdatedAfter *apis.JsonTimeISO8601
+	// A date used for selecting orders that were last updated before (or at) a specified time.
+	LastUpdatedBefore *apis.JsonTimeISO8601
+	// A list of OrderStatus values used to filter the results.
+	OrderStatuses []OrderStatus
+	// A list of MarketplaceId values. Used to select orders that were placed in the specified marketplaces. Required, maximum 50.
+	MarketplaceIDs []constants.MarketplaceID
+	// A list that indicates how an order was fulfilled.
+	FulfillmentChannels []FulfillmentChannel
+	// A list of payment method values. Possible values: COD, CVS, Other.
+	PaymentMethods []string
+	// The email address of a buyer. Used to select orders that contain the specified email address.
+	BuyerEmail *string
+	// An order identifier that is specified by the seller. Used to select only the orders that match the order identifier.
+	SellerOrderID *string
+	// A number that indicates the maximum number of orders that can be returned per page. Minimum 1, maximum 100.
+	MaxResultsPerPage *int
+	// A list of AmazonOrderId values. Maximum 50.
+	AmazonOrderIDs []string
+	// A string token returned in the response of your previous request.
+	NextToken *string
+}
+
+// GetMarketplaceIDs implements apis.MarketplaceScoped.
+func (f *GetOrdersFilter) GetMarketplaceIDs() []constants.MarketplaceID {
+	return f.MarketplaceIDs
+}
+
+// GetQuery returns the query parameters for GetOrdersFilter.
+func (f *GetOrdersFilter) GetQuery() url.Values {
+	q := url.Values{}
+	if f.CreatedAfter != nil {
+		q.Add("CreatedAfter", f.CreatedAfter.String())
+	}
+	if f.CreatedBefore != nil {
+		q.Add("CreatedBefore", f.CreatedBefore.String())
+	}
+	if f.LastUpdatedAfter != nil {
+		q.Add("LastUpdatedAfter", f.LastUpdatedAfter.String())
+	}
+	if f.LastUpdatedBefore != nil {
+		q.Add("LastUpdatedBefore", f.LastUpdatedBefore.String())
+	}
+	utils.AddToQueryIfSet(q, "OrderStatuses", utils.MapToCommaString(f.OrderStatuses))
+	utils.AddToQueryIfSet(q, "MarketplaceIds", utils.MapToCommaString(f.MarketplaceIDs))
+	utils.AddToQueryIfSet(q, "FulfillmentChannels", utils.MapToCommaString(f.FulfillmentChannels))
+	utils.AddToQueryIfSet(q, "PaymentMethods", utils.MapToCommaString(f.PaymentMethods))
+	utils.AddToQueryIfSet(q, "AmazonOrderIds", utils.MapToCommaString(f.AmazonOrderIDs))
+	if f.BuyerEmail != nil {
+		q.Add("BuyerEmail", *f.BuyerEmail)
+	}
+	if f.SellerOrderID != nil {
+		q.Add("SellerOrderId", *f.SellerOrderID)
+	}
+	if f.MaxResultsPerPage != nil {
+		q.Add("MaxResultsPerPage", strconv.Itoa(*f.MaxResultsPerPage))
+	}
+	if f.NextToken != nil {
+		q.Add("NextToken", *f.NextToken)
+	}
+
+	return q
+}
+
+// GetOrdersResponse The response schema for the getOrders operation.
+type GetOrdersResponse struct {
+	Payload *OrdersList `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// OrdersList A list of orders along with additional information to make subsequent API calls.
+type OrdersList struct {
+	Orders []Order `json:"Orders"`
+	// When present and not empty, pass this string token in the next request to return the next response page.
+	NextToken *string `json:"NextToken,omitempty"`
+	// A date used for selecting orders that were last updated before (or at) a specified time.
+	LastUpdatedBefore *string `json:"LastUpdatedBefore,omitempty"`
+	// A date used for selecting orders created before (or at) a specified time.
+	CreatedBefore *string `json:"CreatedBefore,omitempty"`
+}
+
+// GetOrderResponse The response schema for the getOrder operation.
+type GetOrderResponse struct {
+	Payload *Order `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// Order information.
+type Order struct {
+	// An Amazon-defined order identifier, in 3-7-7 format.
+	AmazonOrderId string `json:"AmazonOrderId"`
+	// A seller-defined order identifier.
+	SellerOrderId *string `json:"SellerOrderId,omitempty"`
+	// The date when the order was created.
+	PurchaseDate time.Time `json:"PurchaseDate"`
+	// The date when the order was last updated.
+	LastUpdateDate time.Time `json:"LastUpdateDate"`
+	// The current order status.
+	OrderStatus OrderStatus `json:"OrderStatus"`
+	// Whether the order was fulfilled by Amazon (AFN) or by the seller (MFN).
+	FulfillmentChannel *FulfillmentChannel `json:"FulfillmentChannel,omitempty"`
+	// The sales channel of the first item in the order.
+	SalesChannel *string `json:"SalesChannel,omitempty"`
+	// The order channel of the first item in the order.
+	OrderChannel *string `json:"OrderChannel,omitempty"`
+	// The shipment service level of the order.
+	ShipServiceLevel *string `json:"ShipServiceLevel,omitempty"`
+	OrderTotal       *Money  `json:"OrderTotal,omitempty"`
+	// The number of items shipped.
+	NumberOfItemsShipped *int `json:"NumberOfItemsShipped,omitempty"`
+	// The number of items unshipped.
+	NumberOfItemsUnshipped *int `json:"NumberOfItemsUnshipped,omitempty"`
+	// The payment method for the order. Possible values: COD, CVS, Other.
+	PaymentMethod *string `json:"PaymentMethod,omitempty"`
+	// A list of payment method detail items.
+	PaymentMethodDetails []string `json:"PaymentMethodDetails,omitempty"`
+	// The identifier for the marketplace where the order was placed.
+	MarketplaceId *string `json:"MarketplaceId,omitempty"`
+	// The shipment service level category of the order. Possible values: Expedited, FreeEconomy, NextDay, SameDay, SecondDay, Scheduled, Standard.
+	ShipmentServiceLevelCategory *string `json:"ShipmentServiceLevelCategory,omitempty"`
+	// The type of the order. Possible values: StandardOrder, LongLeadTimeOrder, Preorder, BackOrder, SourcingOnDemandOrder.
+	OrderType *string `json:"OrderType,omitempty"`
+	// The start of the time period within which you have committed to ship the order.
+	EarliestShipDate *time.Time `json:"EarliestShipDate,omitempty"`
+	// The end of the time period within which you have committed to ship the order.
+	LatestShipDate *time.Time `json:"LatestShipDate,omitempty"`
+	// The start of the time period within which you have committed to fulfill the order.
+	EarliestDeliveryDate *time.Time `json:"EarliestDeliveryDate,omitempty"`
+	// The end of the time period within which you have committed to fulfill the order.
+	LatestDeliveryDate *time.Time `json:"LatestDeliveryDate,omitempty"`
+	// When true, the order is an Amazon Business order.
+	IsBusinessOrder *bool `json:"IsBusinessOrder,omitempty"`
+	// When true, the order is a seller-fulfilled Amazon Prime order.
+	IsPrime *bool `json:"IsPrime,omitempty"`
+	// When true, the order has a Premium Shipping Service Level Agreement.
+	IsPremiumOrder *bool `json:"IsPremiumOrder,omitempty"`
+	// When true, the order is a GlobalExpress order.
+	IsGlobalExpressEnabled *bool `json:"IsGlobalExpressEnabled,omitempty"`
+	// The order ID value for the order that is being replaced.
+	ReplacedOrderId *string `json:"ReplacedOrderId,omitempty"`
+	// When true, this is a replacement order.
+	IsReplacementOrder *bool `json:"IsReplacementOrder,omitempty"`
+	// When true, the item within this order was bought and re-sold by Amazon Business EU SARL (ABEU).
+	IsSoldByAB *bool `json:"IsSoldByAB,omitempty"`
+	// When true, the order was placed with "In-Store Pickup".
+	IsISPU *bool `json:"IsISPU,omitempty"`
+	// When true, this order is marked to be delivered to an Access Point.
+	IsAccessPointOrder *bool `json:"IsAccessPointOrder,omitempty"`
+	// Friendly name registered in the marketplace where the sale took place.
+	SellerDisplayName *string `json:"SellerDisplayName,omitempty"`
+	// The shipping address for the order. Only returned with a Restricted Data Token.
+	ShippingAddress *Address `json:"ShippingAddress,omitempty"`
+	// Buyer information. Only returned with a Restricted Data Token.
+	BuyerInfo *BuyerInfo `json:"BuyerInfo,omitempty"`
+	// Whether the order contains regulated items which may require additional approval steps before being fulfilled.
+	HasRegulatedItems *bool `json:"HasRegulatedItems,omitempty"`
+	// The status of the electronic invoice. Only available for Easy Ship orders and orders in the BR marketplace.
+	ElectronicInvoiceStatus *string `json:"ElectronicInvoiceStatus,omitempty"`
+}
+
+// Money The monetary value of the order.
+type Money struct {
+	// The three-digit currency code. In ISO 4217 format.
+	CurrencyCode *string `json:"CurrencyCode,omitempty"`
+	// The currency amount.
+	Amount *apis.Amount `json:"Amount,omitempty"`
+}
+
+// Address The shipping address for the order.
+type Address struct {
+	// The name.
+	Name string `json:"Name"`
+	// The company name of the recipient.
+	CompanyName *string `json:"CompanyName,omitempty"`
+	// The street address.
+	AddressLine1 *string `json:"AddressLine1,omitempty"`
+	// Additional street address information, if required.
+	AddressLine2 *string `json:"AddressLine2,omitempty"`
+	// Additional street address information, if required.
+	AddressLine3 *string `json:"AddressLine3,omitempty"`
+	// The city.
+	City *string `json:"City,omitempty"`
+	// The county.
+	County *string `json:"County,omitempty"`
+	// The district.
+	District *string `json:"District,omitempty"`
+	// The state or region.
+	StateOrRegion *string `json:"StateOrRegion,omitempty"`
+	// The municipality.
+	Municipality *string `json:"Municipality,omitempty"`
+	// The postal code.
+	PostalCode *string `json:"PostalCode,omitempty"`
+	// The country code. A two-character country code, in ISO 3166-1 alpha-2 format.
+	CountryCode *string `json:"CountryCode,omitempty"`
+	// The phone number. Not returned for Fulfillment by Amazon (FBA) orders.
+	Phone *string `json:"Phone,omitempty"`
+	// The address type of the shipping address. Possible values: Residential, Commercial.
+	AddressType *string `json:"AddressType,omitempty"`
+}
+
+// BuyerInfo Buyer information.
+type BuyerInfo struct {
+	// The anonymized email address of the buyer.
+	BuyerEmail *string `json:"BuyerEmail,omitempty"`
+	// The buyer name or the recipient name.
+	BuyerName *string `json:"BuyerName,omitempty"`
+	// The county of the buyer.
+	BuyerCounty *string `json:"BuyerCounty,omitempty"`
+	// Tax information about the buyer.
+	BuyerTaxInfo *BuyerTaxInfo `json:"BuyerTaxInfo,omitempty"`
+	// The purchase order (PO) number entered by the buyer at checkout.
+	PurchaseOrderNumber *string `json:"PurchaseOrderNumber,omitempty"`
+}
+
+// BuyerTaxInfo Tax information about the buyer.
+type BuyerTaxInfo struct {
+	// The legal name of the company.
+	CompanyLegalName *string `json:"CompanyLegalName,omitempty"`
+	// The country or region imposing the tax.
+	TaxingRegion *string `json:"TaxingRegion,omitempty"`
+	// A list of tax classifications that apply to the order.
+	TaxClassifications []TaxClassification `json:"TaxClassifications,omitempty"`
+}
+
+// TaxClassification The tax classification for the order.
+type TaxClassification struct {
+	// The type of tax.
+	Name *string `json:"Name,omitempty"`
+	// The buyer's tax identifier.
+	Value *string `json:"Value,omitempty"`
+}
+
+// GetOrderBuyerInfoResponse The response schema for the getOrderBuyerInfo operation.
+type GetOrderBuyerInfoResponse struct {
+	Payload *OrderBuyerInfo `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// OrderBuyerInfo Buyer information for an order.
+type OrderBuyerInfo struct {
+	// An Amazon-defined order identifier, in 3-7-7 format.
+	AmazonOrderId string `json:"AmazonOrderId"`
+	BuyerInfo
+}
+
+// GetOrderAddressResponse The response schema for the getOrderAddress operation.
+type GetOrderAddressResponse struct {
+	Payload *OrderAddress `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// OrderAddress The shipping address for the order.
+type OrderAddress struct {
+	// An Amazon-defined order identifier, in 3-7-7 format.
+	AmazonOrderId string `json:"AmazonOrderId"`
+	// The company name of the contact buyer. For IBA orders, the buyer company must be Amazon entities.
+	BuyerCompanyName *string  `json:"BuyerCompanyName,omitempty"`
+	ShippingAddress  *Address `json:"ShippingAddress,omitempty"`
+}
+
+// GetOrderItemsResponse The response schema for the getOrderItems operation.
+type GetOrderItemsResponse struct {
+	Payload *OrderItemsList `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// OrderItemsList The order items list along with the order ID.
+type OrderItemsList struct {
+	OrderItems []OrderItem `json:"OrderItems"`
+	// When present and not empty, pass this string token in the next request to return the next response page.
+	NextToken *string `json:"NextToken,omitempty"`
+	// An Amazon-defined order identifier, in 3-7-7 format.
+	AmazonOrderId string `json:"AmazonOrderId"`
+}
+
+// OrderItem A single order item.
+type OrderItem struct {
+	// The Amazon Standard Identification Number (ASIN) of the item.
+	ASIN string `json:"ASIN"`
+	// The seller stock keeping unit (SKU) of the item.
+	SellerSKU *string `json:"SellerSKU,omitempty"`
+	// An Amazon-defined order item identifier.
+	OrderItemId string `json:"OrderItemId"`
+	// The name of the item.
+	Title *string `json:"Title,omitempty"`
+	// The number of items in the order.
+	QuantityOrdered int `json:"QuantityOrdered"`
+	// The number of items shipped.
+	QuantityShipped *int `json:"QuantityShipped,omitempty"`
+	// The selling price of the order item. Note that an order item is an item and a quantity.
+	ItemPrice *Money `json:"ItemPrice,omitempty"`
+	// The shipping price of the item.
+	ShippingPrice *Money `json:"ShippingPrice,omitempty"`
+	// The tax on the item price.
+	ItemTax *Money `json:"ItemTax,omitempty"`
+	// The tax on the shipping price.
+	ShippingTax *Money `json:"ShippingTax,omitempty"`
+	// The discount on the shipping price.
+	ShippingDiscount *Money `json:"ShippingDiscount,omitempty"`
+	// The total of all promotional discounts in the offer.
+	PromotionDiscount *Money `json:"PromotionDiscount,omitempty"`
+	// A list of promotion identifiers provided by the seller when the promotions were created.
+	PromotionIds []string `json:"PromotionIds,omitempty"`
+	// When true, the item is a gift.
+	IsGift *string `json:"IsGift,omitempty"`
+	// The condition of the item. Possible values: New, Used, Collectible, Refurbished, Preorder, Club.
+	ConditionId *string `json:"ConditionId,omitempty"`
+	// The subcondition of the item.
+	ConditionSubtypeId *string `json:"ConditionSubtypeId,omitempty"`
+	// The condition of the item as described by the seller.
+	ConditionNote *string `json:"ConditionNote,omitempty"`
+	// A list of serial numbers for electronic products that are shipped to customers.
+	SerialNumbers []string `json:"SerialNumbers,omitempty"`
+	// The IOSS number of the marketplace.
+	IossNumber *string `json:"IossNumber,omitempty"`
+	// Information about whether or not a buyer requested cancellation.
+	BuyerRequestedCancel *BuyerRequestedCancel `json:"BuyerRequestedCancel,omitempty"`
+	// Information about the buyer of the item. Only returned with a Restricted Data Token.
+	BuyerInfo *ItemBuyerInfo `json:"BuyerInfo,omitempty"`
+}
+
+// BuyerRequestedCancel Information about whether or not a buyer requested cancellation.
+type BuyerRequestedCancel struct {
+	// Indicate whether the buyer has requested cancellation. Possible values: true, false.
+	IsBuyerRequestedCancel *string `json:"IsBuyerRequestedCancel,omitempty"`
+	// The reason that the buyer requested cancellation.
+	BuyerCancelReason *string `json:"BuyerCancelReason,omitempty"`
+}
+
+// ItemBuyerInfo A single item's buyer information.
+type ItemBuyerInfo struct {
+	// The gift wrap price of the item.
+	GiftWrapPrice *Money `json:"GiftWrapPrice,omitempty"`
+	// The tax on the gift wrap price.
+	GiftWrapTax *Money `json:"GiftWrapTax,omitempty"`
+	// A gift message provided by the buyer.
+	GiftMessageText *string `json:"GiftMessageText,omitempty"`
+	// The gift wrap level specified by the buyer.
+	GiftWrapLevel *string `json:"GiftWrapLevel,omitempty"`
+}
+
+// GetOrderRegulatedInfoResponse The response schema for the getOrderRegulatedInfo operation.
+type GetOrderRegulatedInfoResponse struct {
+	Payload *OrderRegulatedInfo `json:"payload,omitempty"`
+	// A list of error responses returned when a request is unsuccessful.
+	Errors []apis.Error `json:"errors,omitempty"`
+}
+
+// OrderRegulatedInfo The order's regulated information along with its verification status.
+type OrderRegulatedInfo struct {
+	// An Amazon-defined order identifier, in 3-7-7 format.
+	AmazonOrderId string `json:"AmazonOrderId"`
+	// The regulated information collected during purchase and used to verify the order.
+	RegulatedInformation RegulatedInformation `json:"RegulatedInformation"`
+	// When true, the order requires attaching a dosage information label when shipped.
+	RequiresDosageLabel bool `json:"RequiresDosageLabel"`
+	// The verification status of the order along with associated approval or rejection metadata.
+	RegulatedOrderVerificationStatus RegulatedOrderVerificationStatus `json:"RegulatedOrderVerificationStatus"`
+}
+
+// RegulatedInformation The regulated information collected during purchase and used to verify the order.
+type RegulatedInformation struct {
+	// A list of regulated information fields as collected from the regulatory form.
+	Fields []RegulatedInformationField `json:"Fields"`
+}
+
+// RegulatedInformationField A field collected from the regulatory form.
+type RegulatedInformationField struct {
+	// The unique identifier of the field.
+	FieldId string `json:"FieldId"`
+	// The name of the field.
+	FieldLabel string `json:"FieldLabel"`
+	// The type of field. Possible values: Text, FileAttachment.
+	FieldType string `json:"FieldType"`
+	// The content of the field as collected in regulatory form.
+	FieldValue string `json:"FieldValue"`
+}
+
+// RegulatedOrderVerificationStatus The verification status of the order, along with associated approval or rejection metadata.
+type RegulatedOrderVerificationStatus struct {
+	// The verification status of the order. Possible values: Pending, Approved, Rejected, Expired, Cancelled.
+	Status string `json:"Status"`
+	// When true, the regulated information provided in the order requires a review by the merchant.
+	RequiresMerchantAction bool `json:"RequiresMerchantAction"`
+	// A list of valid rejection reasons that may be used to reject the order's regulated information.
+	ValidRejectionReasons []RejectionReason `json:"ValidRejectionReasons"`
+	// The reason for rejecting the order's regulated information.
+	RejectionReason *RejectionReason `json:"RejectionReason,omitempty"`
+	// The date the order was reviewed.
+	ReviewDate *time.Time `json:"ReviewDate,omitempty"`
+	// The identifier for the order's regulated information reviewer.
+	ExternalReviewerId *string `json:"ExternalReviewerId,omitempty"`
+}
+
+// RejectionReason The reason for rejecting the order's regulated information.
+type RejectionReason struct {
+	// The unique identifier for the rejection reason.
+	RejectionReasonId string `json:"RejectionReasonId"`
+	// The description of this rejection reason.
+	RejectionReasonDescription string `json:"RejectionReasonDescription"`
+}
+
+// UpdateVerificationStatusRequest The request body for the updateVerificationStatus operation.
+type UpdateVerificationStatusRequest struct {
+	RegulatedOrderVerificationStatus UpdateVerificationStatusRequestBody `json:"regulatedOrderVerificationStatus"`
+}
+
+// UpdateVerificationStatusRequestBody The updated values of the VerificationStatus field.
+type UpdateVerificationStatusRequestBody struct {
+	// The new verification status of the order. Possible values: Approved, Rejected.
+	Status *string `json:"status,omitempty"`
+	// The identifier for the order's regulated information reviewer.
+	ExternalReviewerId string `json:"externalReviewerId"`
+	// The unique identifier for the rejection reason used for rejecting the order's regulated information. Only required if the new status is rejected.
+	RejectionReasonId *string `json:"rejectionReasonId,omitempty"`
+}
+
+// ConfirmShipmentRequest The request schema for a shipment confirmation.
+type ConfirmShipmentRequest struct {
+	PackageDetail PackageDetail `json:"packageDetail"`
+	// The COD collection method, only supported in the JP marketplace. Possible values: DirectPayment.
+	CodCollectionMethod *string `json:"codCollectionMethod,omitempty"`
+	// The unobfuscated marketplace identifier.
+	MarketplaceId constants.MarketplaceID `json:"marketplaceId"`
+}
+
+// PackageDetail Properties of packages.
+type PackageDetail struct {
+	// A seller-supplied identifier that uniquely identifies a package within the scope of an order. Only positive numeric values are supported.
+	PackageReferenceId string `json:"packageReferenceId"`
+	// Identifies the carrier that will deliver the package.
+	CarrierCode string `json:"carrierCode"`
+	// Carrier Name that will deliver the package. Required when carrierCode is "Others".
+	CarrierName *string `json:"carrierName,omitempty"`
+	// Ship method to be used for shipping the order.
+	ShippingMethod *string `json:"shippingMethod,omitempty"`
+	// The tracking number used to obtain tracking and delivery information.
+	TrackingNumber string `json:"trackingNumber"`
+	// The shipping date for the package.
+	ShipDate apis.JsonTimeISO8601 `json:"shipDate"`
+	// The unique identifier of the supply source.
+	ShipFromSupplySourceId *string `json:"shipFromSupplySourceId,omitempty"`
+	// A list of order items.
+	OrderItems []ConfirmShipmentOrderItem `json:"orderItems"`
+}
+
+// ConfirmShipmentOrderItem A single order item.
+type ConfirmShipmentOrderItem struct {
+	// The unique identifier of the order item.
+	OrderItemId string `json:"orderItemId"`
+	// The quantity of the item.
+	Quantity int `json:"quantity"`
+	// A list of order items.
+	TransparencyCodes []string `json:"transparencyCodes,omitempty"`
+}
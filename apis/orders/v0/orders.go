@@ -0,0 +1,142 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"go/types"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+)
+
+const pathPrefix = "/orders/v0"
+
+// piiDataElements are requested by automatically acquired RDTs for the getOrders and getOrder operations.
+var piiDataElements = []string{"buyerInfo", "shippingAddress"}
+
+type API struct {
+	httpClient *httpx.Client
+}
+
+func NewAPI(httpClient *httpx.Client) *API {
+	return &API{
+		httpClient: httpClient,
+	}
+}
+
+// GetOrders returns orders created or updated during the time frame indicated by the specified parameters.
+// A restrictedDataToken is optional and may be passed to receive Personally Identifiable Information (PII).
+// If it is nil and automatic RDT acquisition is enabled, it is fetched automatically.
+func (a *API) GetOrders(filter *GetOrdersFilter, restrictedDataToken *string) (*apis.CallResponse[GetOrdersResponse], error) {
+	if filter.MaxResultsPerPage != nil && (*filter.MaxResultsPerPage < 1 || *filter.MaxResultsPerPage > 100) {
+		return nil, errors.New("maxResultsPerPage must be between 1 and 100")
+	}
+	if filter.NextToken == nil && len(filter.MarketplaceIDs) == 0 {
+		return nil, errors.New("marketplaceIds is required")
+	}
+
+	return apis.NewCall[GetOrdersResponse](http.MethodGet, pathPrefix+"/orders").
+		WithQueryParams(filter.GetQuery()).
+		WithRateLimit(0.0167, time.Second).
+		WithRestrictedDataToken(restrictedDataToken).
+		WithRestrictedResource(piiDataElements...).
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+}
+
+// GetOrder returns the order that you specify.
+// A restrictedDataToken is optional and may be passed to receive Personally Identifiable Information (PII).
+// If it is nil and automatic RDT acquisition is enabled, it is fetched automatically.
+func (a *API) GetOrder(orderID string, restrictedDataToken *string) (*apis.CallResponse[GetOrderResponse], error) {
+	return apis.NewCall[GetOrderResponse](http.MethodGet, pathPrefix+"/orders/"+orderID).
+		WithRateLimit(0.5, time.Second).
+		WithRestrictedDataToken(restrictedDataToken).
+		WithRestrictedResource(piiDataElements...).
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+}
+
+// GetOrderBuyerInfo returns buyer information for the order that you specify.
+// A restrictedDataToken is required, unless automatic RDT acquisition is enabled, to receive the buyer information.
+func (a *API) GetOrderBuyerInfo(orderID string, restrictedDataToken *string) (*apis.CallResponse[GetOrderBuyerInfoResponse], error) {
+	return apis.NewCall[GetOrderBuyerInfoResponse](http.MethodGet, pathPrefix+"/orders/"+orderID+"/buyerInfo").
+		WithRateLimit(0.5, time.Second).
+		WithRestrictedDataToken(restrictedDataToken).
+		WithRestrictedResource().
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+}
+
+// GetOrderAddress returns the shipping address for the order that you specify.
+// A restrictedDataToken is required, unless automatic RDT acquisition is enabled, to receive the shipping address.
+func (a *API) GetOrderAddress(orderID string, restrictedDataToken *string) (*apis.CallResponse[GetOrderAddressResponse], error) {
+	return apis.NewCall[GetOrderAddressResponse](http.MethodGet, pathPrefix+"/orders/"+orderID+"/address").
+		WithRateLimit(0.5, time.Second).
+		WithRestrictedDataToken(restrictedDataToken).
+		WithRestrictedResource().
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+}
+
+// GetOrderItems returns detailed order item information for the order that you specify.
+// nextToken is optional and fetches the next page of order items.
+// A restrictedDataToken is optional and may be passed to receive Personally Identifiable Information (PII).
+// If it is nil and automatic RDT acquisition is enabled, it is fetched automatically.
+func (a *API) GetOrderItems(orderID string, nextToken *string, restrictedDataToken *string) (*apis.CallResponse[GetOrderItemsResponse], error) {
+	call := apis.NewCall[GetOrderItemsResponse](http.MethodGet, pathPrefix+"/orders/"+orderID+"/orderItems").
+		WithRateLimit(0.5, time.Second).
+		WithRestrictedDataToken(restrictedDataToken).
+		WithRestrictedResource("buyerInfo").
+		WithParseErrorListOnError()
+
+	if nextToken != nil {
+		call = call.WithQueryParams(url.Values{"NextToken": {*nextToken}})
+	}
+
+	return call.Execute(a.httpClient)
+}
+
+// GetOrderRegulatedInfo returns regulated information for the order that you specify.
+// A restrictedDataToken is optional and may be passed to receive Personally Identifiable Information (PII).
+// If it is nil and automatic RDT acquisition is enabled, it is fetched automatically.
+func (a *API) GetOrderRegulatedInfo(orderID string, restrictedDataToken *string) (*apis.CallResponse[GetOrderRegulatedInfoResponse], error) {
+	return apis.NewCall[GetOrderRegulatedInfoResponse](http.MethodGet, pathPrefix+"/orders/"+orderID+"/regulatedInfo").
+		WithRateLimit(0.5, time.Second).
+		WithRestrictedDataToken(restrictedDataToken).
+		WithRestrictedResource().
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+}
+
+// UpdateVerificationStatus updates (approves or rejects) the verification status of an order containing regulated products.
+func (a *API) UpdateVerificationStatus(orderID string, request *UpdateVerificationStatusRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = apis.NewCall[types.Nil](http.MethodPatch, pathPrefix+"/orders/"+orderID+"/regulatedInfo").
+		WithBody(body).
+		WithRateLimit(0.5, time.Second).
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+	return err
+}
+
+// ConfirmShipment updates the shipment confirmation status for the order that you specify.
+func (a *API) ConfirmShipment(orderID string, request *ConfirmShipmentRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = apis.NewCall[types.Nil](http.MethodPost, pathPrefix+"/orders/"+orderID+"/shipmentConfirmation").
+		WithBody(body).
+		WithRateLimit(2, time.Second).
+		WithParseErrorListOnError().
+		Execute(a.httpClient)
+	return err
+}
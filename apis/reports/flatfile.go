@@ -0,0 +1,144 @@
+package reports
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+)
+
+// FlatFileRow is a row of a tab-separated flat file report document, keyed by the column names of the header.
+type FlatFileRow map[string]string
+
+// ParseFlatFile parses a tab-separated flat file report document with a header row,
+// e.g. the decompressed content of GET_FBA_REIMBURSEMENTS_DATA.
+func ParseFlatFile(document []byte) ([]FlatFileRow, error) {
+	document = bytes.TrimPrefix(document, []byte("\ufeff"))
+	lines := strings.Split(strings.ReplaceAll(string(document), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, errors.New("flat file has no header row")
+	}
+
+	header := strings.Split(lines[0], "\t")
+	var rows []FlatFileRow
+	for n, line := range lines[1:] {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) > len(header) {
+			return nil, fmt.Errorf("line %d has %d columns, the header has %d", n+2, len(fields), len(header))
+		}
+		row := make(FlatFileRow, len(header))
+		for i, column := range header {
+			if i < len(fields) {
+				row[strings.TrimSpace(column)] = strings.TrimSpace(fields[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Amount parses the column as apis.Amount. An empty or missing column is 0.
+func (r FlatFileRow) Amount(column string) (apis.Amount, error) {
+	value := r[column]
+	if value == "" {
+		return apis.Amount{}, nil
+	}
+	amount, err := apis.ParseAmount(value)
+	if err != nil {
+		return apis.Amount{}, fmt.Errorf("column %s: %w", column, err)
+	}
+	return amount, nil
+}
+
+// Int parses the column as integer. An empty or missing column is 0.
+func (r FlatFileRow) Int(column string) (int, error) {
+	value := r[column]
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("column %s: %w", column, err)
+	}
+	return n, nil
+}
+
+// Time parses the column as RFC 3339 timestamp. An empty or missing column is the zero time.
+func (r FlatFileRow) Time(column string) (time.Time, error) {
+	value := r[column]
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("column %s: %w", column, err)
+	}
+	return t, nil
+}
+
+// Reimbursement is a row of the FBAReimbursementsReport.
+type Reimbursement struct {
+	ApprovalDate                time.Time
+	ReimbursementID             string
+	CaseID                      string
+	AmazonOrderID               string
+	Reason                      string
+	SKU                         string
+	FNSKU                       string
+	ASIN                        string
+	ProductName                 string
+	Condition                   string
+	CurrencyUnit                string
+	AmountPerUnit               apis.Amount
+	AmountTotal                 apis.Amount
+	QuantityReimbursedCash      int
+	QuantityReimbursedInventory int
+	QuantityReimbursedTotal     int
+	OriginalReimbursementID     string
+	OriginalReimbursementType   string
+}
+
+// ParseReimbursementsReport parses the document of an FBAReimbursementsReport.
+func ParseReimbursementsReport(document []byte) ([]Reimbursement, error) {
+	rows, err := ParseFlatFile(document)
+	if err != nil {
+		return nil, err
+	}
+
+	reimbursements := make([]Reimbursement, len(rows))
+	for i, row := range rows {
+		r := Reimbursement{
+			ReimbursementID:           row["reimbursement-id"],
+			CaseID:                    row["case-id"],
+			AmazonOrderID:             row["amazon-order-id"],
+			Reason:                    row["reason"],
+			SKU:                       row["sku"],
+			FNSKU:                     row["fnsku"],
+			ASIN:                      row["asin"],
+			ProductName:               row["product-name"],
+			Condition:                 row["condition"],
+			CurrencyUnit:              row["currency-unit"],
+			OriginalReimbursementID:   row["original-reimbursement-id"],
+			OriginalReimbursementType: row["original-reimbursement-type"],
+		}
+		errs := make([]error, 6)
+		r.ApprovalDate, errs[0] = row.Time("approval-date")
+		r.AmountPerUnit, errs[1] = row.Amount("amount-per-unit")
+		r.AmountTotal, errs[2] = row.Amount("amount-total")
+		r.QuantityReimbursedCash, errs[3] = row.Int("quantity-reimbursed-cash")
+		r.QuantityReimbursedInventory, errs[4] = row.Int("quantity-reimbursed-inventory")
+		r.QuantityReimbursedTotal, errs[5] = row.Int("quantity-reimbursed-total")
+		if err := errors.Join(errs...); err != nil {
+			return nil, fmt.Errorf("reimbursement %s: %w", r.ReimbursementID, err)
+		}
+		reimbursements[i] = r
+	}
+	return reimbursements, nil
+}
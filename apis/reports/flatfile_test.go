@@ -0,0 +1,56 @@
+package reports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReimbursementsReport(t *testing.T) {
+	document := "approval-date\treimbursement-id\tcase-id\tamazon-order-id\treason\tsku\tfnsku\tasin\tproduct-name\tcondition\tcurrency-unit\tamount-per-unit\tamount-total\tquantity-reimbursed-cash\tquantity-reimbursed-inventory\tquantity-reimbursed-total\toriginal-reimbursement-id\toriginal-reimbursement-type\r\n" +
+		"2024-01-15T10:00:00+00:00\t1234567890\t\t302-1234567-1234567\tCustomerReturn\tSKU-1\tX001\tB001\tMug\tSellable\tEUR\t12.34\t24.68\t2\t0\t2\t\t\r\n" +
+		"2024-01-16T10:00:00+00:00\t1234567891\t9876\t\tLost_Warehouse\tSKU-2\tX002\tB002\tCup\tSellable\tJPY\t1200\t1200\t1\t\t1\t\t\r\n"
+
+	reimbursements, err := reports.ParseReimbursementsReport([]byte(document))
+	require.NoError(t, err)
+	require.Len(t, reimbursements, 2)
+
+	first := reimbursements[0]
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), first.ApprovalDate.UTC())
+	assert.Equal(t, "302-1234567-1234567", first.AmazonOrderID)
+	assert.Equal(t, "EUR", first.CurrencyUnit)
+	assert.Equal(t, "24.68", first.AmountTotal.String())
+	assert.Zero(t, first.AmountPerUnit.MulInt(2).Cmp(first.AmountTotal))
+	assert.Equal(t, 2, first.QuantityReimbursedTotal)
+
+	second := reimbursements[1]
+	assert.Equal(t, "9876", second.CaseID)
+	assert.Equal(t, "1200", second.AmountTotal.String())
+	assert.Equal(t, 0, second.QuantityReimbursedInventory)
+}
+
+func TestParseReimbursementsReport_InvalidAmount(t *testing.T) {
+	_, err := reports.ParseReimbursementsReport([]byte("reimbursement-id\tamount-total\n1\t12,34\n"))
+	assert.ErrorContains(t, err, "amount-total")
+}
+
+func TestFlatFileRow_Amount(t *testing.T) {
+	rows, err := reports.ParseFlatFile([]byte("\ufeffsku\tprice\tfee\nSKU-1\t-0.10\t\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	price, err := rows[0].Amount("price")
+	require.NoError(t, err)
+	assert.Equal(t, apis.MustParseAmount("-0.10"), price)
+
+	fee, err := rows[0].Amount("fee")
+	require.NoError(t, err)
+	assert.True(t, fee.IsZero())
+
+	_, err = reports.ParseFlatFile(nil)
+	assert.Error(t, err)
+}
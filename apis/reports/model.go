@@ -75,6 +75,31 @@ const (
 	FBASubscribeAndSavePerformanceReport Type = "GET_FBA_SNS_PERFORMANCE_DATA"
 )
 
+// restrictedTypes are the report types whose documents contain PII and require a Restricted Data Token.
+var restrictedTypes = map[Type]bool{
+	"GET_AMAZON_FULFILLED_SHIPMENTS_DATA_INVOICING":      true,
+	"GET_AMAZON_FULFILLED_SHIPMENTS_DATA_TAX":            true,
+	"GET_FLAT_FILE_ACTIONABLE_ORDER_DATA_SHIPPING":       true,
+	"GET_FLAT_FILE_ORDER_REPORT_DATA_SHIPPING":           true,
+	"GET_FLAT_FILE_ORDER_REPORT_DATA_INVOICING":          true,
+	"GET_FLAT_FILE_ORDER_REPORT_DATA_TAX":                true,
+	"GET_FLAT_FILE_ORDERS_RECONCILIATION_DATA_TAX":       true,
+	"GET_FLAT_FILE_ORDERS_RECONCILIATION_DATA_INVOICING": true,
+	"GET_FLAT_FILE_ORDERS_RECONCILIATION_DATA_SHIPPING":  true,
+	"GET_ORDER_REPORT_DATA_INVOICING":                    true,
+	"GET_ORDER_REPORT_DATA_TAX":                          true,
+	"GET_ORDER_REPORT_DATA_SHIPPING":                     true,
+	"GET_EASYSHIP_DOCUMENTS":                             true,
+	"GET_GST_MTR_B2B_CUSTOM":                             true,
+	"GET_VAT_TRANSACTION_DATA":                           true,
+	"SC_VAT_TAX_REPORT":                                  true,
+}
+
+// IsRestricted reports whether documents of the report type require a Restricted Data Token.
+func (t Type) IsRestricted() bool {
+	return restrictedTypes[t]
+}
+
 // ReportModel Detailed information about the report.
 type ReportModel struct {
 	// A list of marketplace identifiers for the report.
@@ -140,6 +165,11 @@ type GetReportsFilter struct {
 	NextToken string
 }
 
+// GetMarketplaceIDs implements apis.MarketplaceScoped.
+func (f *GetReportsFilter) GetMarketplaceIDs() []constants.MarketplaceID {
+	return f.MarketplaceIDs
+}
+
 func (f *GetReportsFilter) GetQuery() url.Values {
 	q := url.Values{}
 	q.Add("reportTypes", utils.MapToCommaString(f.ReportTypes))
@@ -166,6 +196,11 @@ type CreateReportSpecification struct {
 	MarketplaceIDs []constants.MarketplaceID `json:"marketplaceIds"`
 }
 
+// GetMarketplaceIDs implements apis.MarketplaceScoped.
+func (s *CreateReportSpecification) GetMarketplaceIDs() []constants.MarketplaceID {
+	return s.MarketplaceIDs
+}
+
 // GetReportDocumentResponse Response schema.
 type GetReportDocumentResponse struct {
 	ReportDocument
@@ -245,3 +280,8 @@ type CreateReportScheduleSpecification struct {
 	// The date and time when the schedule will create its next report, in ISO 8601 date time format.
 	NextReportCreationTime apis.JsonTimeISO8601 `json:"nextReportCreationTime,omitempty"`
 }
+
+// GetMarketplaceIDs implements apis.MarketplaceScoped.
+func (s *CreateReportScheduleSpecification) GetMarketplaceIDs() []constants.MarketplaceID {
+	return s.MarketplaceIDs
+}
@@ -116,9 +116,29 @@ func (r *API) CancelReportSchedule(reportScheduleID string) error {
 
 // GetReportDocument returns the information required for retrieving a report document's contents.
 // a restrictedDataToken is optional and may be passed to receive Personally Identifiable Information (PII).
+// No RDT is fetched automatically, use GetReportDocumentFor for documents of restricted reports.
 func (r *API) GetReportDocument(reportDocumentID string, restrictedDataToken *string) (*apis.CallResponse[GetReportDocumentResponse], error) {
-	return apis.NewCall[GetReportDocumentResponse](http.MethodGet, pathPrefix+"/documents/"+reportDocumentID).
-		WithRestrictedDataToken(restrictedDataToken).
+	return r.getReportDocument(reportDocumentID, restrictedDataToken, false)
+}
+
+// GetReportDocumentFor returns the document of the report. If no restrictedDataToken is passed and
+// automatic RDT acquisition is enabled, an RDT is fetched for restricted report types, which costs
+// an additional createRestrictedDataToken call per document. Other documents are requested with
+// the regular access token.
+func (r *API) GetReportDocumentFor(report *ReportModel, restrictedDataToken *string) (*apis.CallResponse[GetReportDocumentResponse], error) {
+	if report.ReportDocumentID == nil {
+		return nil, fmt.Errorf("report %s has no document", report.ReportID)
+	}
+	return r.getReportDocument(*report.ReportDocumentID, restrictedDataToken, report.ReportType.IsRestricted())
+}
+
+func (r *API) getReportDocument(reportDocumentID string, restrictedDataToken *string, restricted bool) (*apis.CallResponse[GetReportDocumentResponse], error) {
+	call := apis.NewCall[GetReportDocumentResponse](http.MethodGet, pathPrefix+"/documents/"+reportDocumentID).
+		WithRestrictedDataToken(restrictedDataToken)
+	if restricted {
+		call = call.WithRestrictedResource()
+	}
+	return call.
 		WithParseErrorListOnError().
 		WithRateLimit(0.0167, time.Second).
 		Execute(r.httpClient)
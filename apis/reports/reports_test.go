@@ -0,0 +1,75 @@
+package reports_test
+
+import (
+	"testing"
+
+	sp_api "github.com/fond-of-vertigo/amazon-sp-api"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/spapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_GetReportDocumentFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		reportType reports.Type
+		wantToken  string
+		wantRDTs   int
+	}{
+		{name: "Non-restricted report uses the access token", reportType: reports.FBAAmazonFulfilledInventoryReport, wantToken: spapitest.AccessToken},
+		{name: "Restricted report fetches an RDT", reportType: reports.FBAAmazonFulfilledShipmentsInvoicing, wantToken: spapitest.RestrictedDataToken, wantRDTs: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := spapitest.NewServer(t)
+			s.ScriptReport(tt.reportType, spapitest.ReportScript{Statuses: []constants.ProcessingStatus{constants.Done}})
+			client := s.NewClient(sp_api.Config{AutoRestrictedDataToken: true})
+
+			created, err := client.ReportsAPI.CreateReport(&reports.CreateReportSpecification{
+				ReportType:     tt.reportType,
+				MarketplaceIDs: []constants.MarketplaceID{constants.Germany},
+			})
+			require.NoError(t, err)
+			report, err := client.ReportsAPI.GetReport(created.ResponseBody.ReportID)
+			require.NoError(t, err)
+
+			_, err = client.ReportsAPI.GetReportDocumentFor(&report.ResponseBody.ReportModel, nil)
+			require.NoError(t, err)
+
+			s.AssertCalled(t, spapitest.OperationCreateRestrictedDataToken, tt.wantRDTs)
+			requests := s.Requests(spapitest.OperationGetReportDocument)
+			require.Len(t, requests, 1)
+			assert.Equal(t, tt.wantToken, requests[0].Header.Get(constants.AccessTokenHeader))
+		})
+	}
+}
+
+func TestAPI_GetReportDocument_NoAutoRDT(t *testing.T) {
+	s := spapitest.NewServer(t)
+	s.ScriptReport(reports.FBAAmazonFulfilledShipmentsInvoicing, spapitest.ReportScript{Statuses: []constants.ProcessingStatus{constants.Done}})
+	client := s.NewClient(sp_api.Config{AutoRestrictedDataToken: true})
+
+	created, err := client.ReportsAPI.CreateReport(&reports.CreateReportSpecification{
+		ReportType:     reports.FBAAmazonFulfilledShipmentsInvoicing,
+		MarketplaceIDs: []constants.MarketplaceID{constants.Germany},
+	})
+	require.NoError(t, err)
+	report, err := client.ReportsAPI.GetReport(created.ResponseBody.ReportID)
+	require.NoError(t, err)
+
+	_, err = client.ReportsAPI.GetReportDocument(*report.ResponseBody.ReportDocumentID, nil)
+	require.NoError(t, err)
+
+	s.AssertCalled(t, spapitest.OperationCreateRestrictedDataToken, 0)
+	requests := s.Requests(spapitest.OperationGetReportDocument)
+	require.Len(t, requests, 1)
+	assert.Equal(t, spapitest.AccessToken, requests[0].Header.Get(constants.AccessTokenHeader))
+}
+
+func TestType_IsRestricted(t *testing.T) {
+	assert.True(t, reports.FBAAmazonFulfilledShipmentsReportTax.IsRestricted())
+	assert.True(t, reports.Type("GET_FLAT_FILE_ACTIONABLE_ORDER_DATA_SHIPPING").IsRestricted())
+	assert.False(t, reports.FBAFlatFileAllOrdersReportbyOrderDate.IsRestricted())
+}
@@ -0,0 +1,127 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+)
+
+// MaxRestrictedResources is the maximum number of restricted resources per Restricted Data Token.
+const MaxRestrictedResources = 50
+
+var restrictedResourceMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPut:    true,
+	http.MethodPost:   true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// RestrictedDataTokens maps keys, e.g. order IDs, to the Restricted Data Token (RDT)
+// which was issued for the restricted resource of the key.
+type RestrictedDataTokens map[string]string
+
+// Get returns the RDT for the key or nil if no RDT was issued for it.
+// The result can be passed as restrictedDataToken to the API calls.
+func (r RestrictedDataTokens) Get(key string) *string {
+	token, ok := r[key]
+	if !ok {
+		return nil
+	}
+	return &token
+}
+
+// CreateRestrictedDataTokens issues RDTs for many restricted resources with the same method and data elements.
+// pathsByKey maps a key, e.g. an order ID, to the path of its restricted resource. The paths are grouped
+// into chunks of MaxRestrictedResources and one RDT is issued per chunk.
+// The returned RestrictedDataTokens map every key to the RDT of its chunk.
+func (t *API) CreateRestrictedDataTokens(method string, pathsByKey map[string]string, dataElements []string) (RestrictedDataTokens, error) {
+	return createRestrictedDataTokens(t.CreateRestrictedDataTokenRequest, method, pathsByKey, dataElements)
+}
+
+func createRestrictedDataTokens(
+	create func(request *CreateRestrictedDataTokenRequest) (*apis.CallResponse[CreateRestrictedDataTokenResponse], error),
+	method string,
+	pathsByKey map[string]string,
+	dataElements []string,
+) (RestrictedDataTokens, error) {
+	keys := make([]string, 0, len(pathsByKey))
+	for key, path := range pathsByKey {
+		if err := validateRestrictedResource(method, path); err != nil {
+			return nil, fmt.Errorf("invalid restricted resource for %s: %w", key, err)
+		}
+		keys = append(keys, key)
+	}
+	// sorting makes the chunks deterministic
+	sort.Strings(keys)
+
+	tokens := make(RestrictedDataTokens, len(keys))
+	for start := 0; start < len(keys); start += MaxRestrictedResources {
+		end := start + MaxRestrictedResources
+		if end > len(keys) {
+			end = len(keys)
+		}
+		chunk := keys[start:end]
+
+		request := &CreateRestrictedDataTokenRequest{
+			RestrictedResources: make([]RestrictedResource, len(chunk)),
+		}
+		for i, key := range chunk {
+			request.RestrictedResources[i] = RestrictedResource{
+				Method:       method,
+				Path:         pathsByKey[key],
+				DataElements: dataElements,
+			}
+		}
+
+		resp, err := create(request)
+		if err != nil {
+			return nil, err
+		}
+		if resp.ResponseBody == nil || resp.ResponseBody.RestrictedDataToken == nil {
+			return nil, errors.New("createRestrictedDataToken response did not contain a token")
+		}
+		for _, key := range chunk {
+			tokens[key] = *resp.ResponseBody.RestrictedDataToken
+		}
+	}
+	return tokens, nil
+}
+
+func (r *CreateRestrictedDataTokenRequest) validate() error {
+	if len(r.RestrictedResources) == 0 {
+		return errors.New("at least one restricted resource is required")
+	}
+	if len(r.RestrictedResources) > MaxRestrictedResources {
+		return fmt.Errorf("restrictedResources must not contain more than %d resources, got %d",
+			MaxRestrictedResources, len(r.RestrictedResources))
+	}
+	for _, resource := range r.RestrictedResources {
+		if err := validateRestrictedResource(resource.Method, resource.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRestrictedResource checks that the method is supported and the path
+// is an absolute API path without host, query or fragment, e.g. /orders/v0/orders/123-1234567-1234567.
+func validateRestrictedResource(method string, path string) error {
+	if !restrictedResourceMethods[method] {
+		return fmt.Errorf("method %q is not supported", method)
+	}
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path %q must start with /", path)
+	}
+	if strings.ContainsAny(path, "?# \t\r\n") {
+		return fmt.Errorf("path %q must not contain a query, fragment or whitespace", path)
+	}
+	if strings.Contains(path, "//") || strings.HasSuffix(path, "/") {
+		return fmt.Errorf("path %q must not contain empty segments", path)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package tokens
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_createRestrictedDataTokens(t *testing.T) {
+	pathsByKey := map[string]string{}
+	for i := 0; i < 120; i++ {
+		orderID := fmt.Sprintf("123-0000000-%07d", i)
+		pathsByKey[orderID] = "/orders/v0/orders/" + orderID
+	}
+
+	var requests []*CreateRestrictedDataTokenRequest
+	create := func(request *CreateRestrictedDataTokenRequest) (*apis.CallResponse[CreateRestrictedDataTokenResponse], error) {
+		if err := request.validate(); err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+		token := "RDT-" + strconv.Itoa(len(requests))
+		return &apis.CallResponse[CreateRestrictedDataTokenResponse]{
+			Status:       200,
+			ResponseBody: &CreateRestrictedDataTokenResponse{RestrictedDataToken: &token},
+		}, nil
+	}
+
+	tokens, err := createRestrictedDataTokens(create, http.MethodGet, pathsByKey, []string{"shippingAddress"})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 3)
+	assert.Len(t, requests[0].RestrictedResources, 50)
+	assert.Len(t, requests[1].RestrictedResources, 50)
+	assert.Len(t, requests[2].RestrictedResources, 20)
+	assert.Equal(t, []string{"shippingAddress"}, requests[2].RestrictedResources[0].DataElements)
+	assert.Len(t, tokens, 120)
+	assert.Equal(t, "RDT-1", *tokens.Get("123-0000000-0000000"))
+	assert.Equal(t, "RDT-3", *tokens.Get("123-0000000-0000119"))
+	assert.Nil(t, tokens.Get("unknown"))
+}
+
+func Test_validateRestrictedResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		wantErr bool
+	}{
+		{name: "Order path", method: http.MethodGet, path: "/orders/v0/orders/123-1234567-1234567"},
+		{name: "Path with placeholder", method: http.MethodGet, path: "/mfn/v0/shipments/{shipmentID}"},
+		{name: "Unknown method", method: "FETCH", path: "/orders/v0/orders", wantErr: true},
+		{name: "Relative path", method: http.MethodGet, path: "orders/v0/orders", wantErr: true},
+		{name: "Full URL", method: http.MethodGet, path: "https://sellingpartnerapi-eu.amazon.com/orders/v0/orders", wantErr: true},
+		{name: "Query", method: http.MethodGet, path: "/orders/v0/orders?MarketplaceIds=A1PA6795UKMFR9", wantErr: true},
+		{name: "Empty segment", method: http.MethodGet, path: "/orders/v0/orders//orderItems", wantErr: true},
+		{name: "Trailing slash", method: http.MethodGet, path: "/orders/v0/orders/", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRestrictedResource(tt.method, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRestrictedResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateRestrictedDataTokenRequest_validate(t *testing.T) {
+	request := &CreateRestrictedDataTokenRequest{}
+	assert.Error(t, request.validate())
+
+	for i := 0; i < MaxRestrictedResources; i++ {
+		request.RestrictedResources = append(request.RestrictedResources, RestrictedResource{Method: http.MethodGet, Path: "/orders/v0/orders"})
+	}
+	assert.NoError(t, request.validate())
+
+	request.RestrictedResources = append(request.RestrictedResources, RestrictedResource{Method: http.MethodGet, Path: "/orders/v0/orders"})
+	assert.Error(t, request.validate())
+}
@@ -0,0 +1,129 @@
+package tokens
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// RestrictedDataTokenCache fetches Restricted Data Tokens (RDTs) for single restricted resources
+// and caches them until they expire. It implements apis.RestrictedDataTokenProvider.
+// RDTs are bound to the path of the resource, so the cache only saves requests for repeated calls
+// of the same resource, e.g. retries or downloading the same report document again; calls of
+// another order or document always fetch a new RDT.
+type RestrictedDataTokenCache struct {
+	mu       sync.Mutex
+	tokens   map[string]cachedToken
+	inflight map[string]*inflightToken
+	create   func(request *CreateRestrictedDataTokenRequest) (*apis.CallResponse[CreateRestrictedDataTokenResponse], error)
+	now      func() time.Time
+}
+
+type cachedToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// inflightToken is a running request for an RDT, which concurrent calls for the same resource wait for.
+type inflightToken struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// NewRestrictedDataTokenCache returns an empty cache which fetches RDTs with the given API.
+func NewRestrictedDataTokenCache(api *API) *RestrictedDataTokenCache {
+	return &RestrictedDataTokenCache{
+		tokens:   map[string]cachedToken{},
+		inflight: map[string]*inflightToken{},
+		create:   api.CreateRestrictedDataTokenRequest,
+		now:      time.Now,
+	}
+}
+
+// GetRestrictedDataToken returns a cached RDT for the restricted resource or fetches a new one.
+// Concurrent calls for the same resource share one request, calls for other resources are not blocked by it.
+func (c *RestrictedDataTokenCache) GetRestrictedDataToken(method string, path string, dataElements []string) (string, error) {
+	key := cacheKey(method, path, dataElements)
+
+	c.mu.Lock()
+	if cached, ok := c.tokens[key]; ok && c.now().Before(cached.expiresAt) {
+		c.mu.Unlock()
+		return cached.token, nil
+	}
+	if running, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-running.done
+		return running.token, running.err
+	}
+	running := &inflightToken{done: make(chan struct{})}
+	c.inflight[key] = running
+	c.mu.Unlock()
+
+	running.token, running.err = c.fetch(key, method, path, dataElements)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.mu.Unlock()
+	close(running.done)
+	return running.token, running.err
+}
+
+// fetch requests a new RDT and caches it. It must not be called with c.mu held.
+func (c *RestrictedDataTokenCache) fetch(key string, method string, path string, dataElements []string) (string, error) {
+	resp, err := c.create(&CreateRestrictedDataTokenRequest{
+		RestrictedResources: []RestrictedResource{{
+			Method:       method,
+			Path:         path,
+			DataElements: dataElements,
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.ResponseBody == nil || resp.ResponseBody.RestrictedDataToken == nil {
+		return "", errors.New("createRestrictedDataToken response did not contain a token")
+	}
+
+	token := *resp.ResponseBody.RestrictedDataToken
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.removeExpired(now)
+	c.tokens[key] = cachedToken{
+		token:     token,
+		expiresAt: expiresAt(now, resp.ResponseBody.ExpiresIn),
+	}
+	return token, nil
+}
+
+func (c *RestrictedDataTokenCache) removeExpired(now time.Time) {
+	for key, cached := range c.tokens {
+		if !now.Before(cached.expiresAt) {
+			delete(c.tokens, key)
+		}
+	}
+}
+
+// expiresAt returns the time until a token can be used, keeping the ExpiryDelta as puffer.
+func expiresAt(now time.Time, expiresIn *int32) time.Time {
+	if expiresIn == nil {
+		return now
+	}
+	lifetime := time.Duration(*expiresIn) * time.Second
+	if lifetime > constants.ExpiryDelta {
+		lifetime -= constants.ExpiryDelta
+	}
+	return now.Add(lifetime)
+}
+
+func cacheKey(method string, path string, dataElements []string) string {
+	sorted := append([]string(nil), dataElements...)
+	sort.Strings(sorted)
+	return method + " " + path + " " + strings.Join(sorted, ",")
+}
@@ -0,0 +1,101 @@
+package tokens
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestrictedDataTokenCache_GetRestrictedDataToken(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var requests []*CreateRestrictedDataTokenRequest
+	cache := &RestrictedDataTokenCache{
+		tokens:   map[string]cachedToken{},
+		inflight: map[string]*inflightToken{},
+		create: func(request *CreateRestrictedDataTokenRequest) (*apis.CallResponse[CreateRestrictedDataTokenResponse], error) {
+			requests = append(requests, request)
+			token := "RDT-" + request.RestrictedResources[0].Path
+			expiresIn := int32(3600)
+			return &apis.CallResponse[CreateRestrictedDataTokenResponse]{
+				Status:       200,
+				ResponseBody: &CreateRestrictedDataTokenResponse{RestrictedDataToken: &token, ExpiresIn: &expiresIn},
+			}, nil
+		},
+		now: func() time.Time { return now },
+	}
+
+	token, err := cache.GetRestrictedDataToken("GET", "/orders/1", []string{"shippingAddress", "buyerInfo"})
+	assert.NoError(t, err)
+	assert.Equal(t, "RDT-/orders/1", token)
+
+	// same resource with data elements in different order is served from the cache
+	token, err = cache.GetRestrictedDataToken("GET", "/orders/1", []string{"buyerInfo", "shippingAddress"})
+	assert.NoError(t, err)
+	assert.Equal(t, "RDT-/orders/1", token)
+	assert.Len(t, requests, 1)
+
+	// other resources get their own token
+	_, err = cache.GetRestrictedDataToken("GET", "/orders/2", nil)
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+
+	// expired tokens are fetched again
+	now = now.Add(time.Hour)
+	_, err = cache.GetRestrictedDataToken("GET", "/orders/1", []string{"buyerInfo", "shippingAddress"})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 3)
+	assert.Equal(t, []string{"shippingAddress", "buyerInfo"}, requests[0].RestrictedResources[0].DataElements)
+}
+
+func TestRestrictedDataTokenCache_Concurrent(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := map[string]int{}
+	cache := &RestrictedDataTokenCache{
+		tokens:   map[string]cachedToken{},
+		inflight: map[string]*inflightToken{},
+		create: func(request *CreateRestrictedDataTokenRequest) (*apis.CallResponse[CreateRestrictedDataTokenResponse], error) {
+			path := request.RestrictedResources[0].Path
+			mu.Lock()
+			requests[path]++
+			mu.Unlock()
+			if path == "/orders/slow" {
+				<-release
+			}
+			token := "RDT-" + path
+			expiresIn := int32(3600)
+			return &apis.CallResponse[CreateRestrictedDataTokenResponse]{
+				Status:       200,
+				ResponseBody: &CreateRestrictedDataTokenResponse{RestrictedDataToken: &token, ExpiresIn: &expiresIn},
+			}, nil
+		},
+		now: time.Now,
+	}
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 5)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := cache.GetRestrictedDataToken("GET", "/orders/slow", nil)
+			assert.NoError(t, err)
+			tokens[i] = token
+		}(i)
+	}
+
+	// another resource is not blocked by the running request
+	token, err := cache.GetRestrictedDataToken("GET", "/orders/fast", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "RDT-/orders/fast", token)
+
+	close(release)
+	wg.Wait()
+	for _, token := range tokens {
+		assert.Equal(t, "RDT-/orders/slow", token)
+	}
+	assert.Equal(t, map[string]int{"/orders/slow": 1, "/orders/fast": 1}, requests)
+}
@@ -22,7 +22,11 @@ func NewAPI(httpClient *httpx.Client) *API {
 }
 
 // CreateRestrictedDataTokenRequest returns a Restricted Data Token (RDT) for one or more restricted resources that you specify.
+// The request may contain up to MaxRestrictedResources resources.
 func (t *API) CreateRestrictedDataTokenRequest(restrictedResources *CreateRestrictedDataTokenRequest) (*apis.CallResponse[CreateRestrictedDataTokenResponse], error) {
+	if err := restrictedResources.validate(); err != nil {
+		return nil, err
+	}
 	body, err := json.Marshal(restrictedResources)
 	if err != nil {
 		return nil, err
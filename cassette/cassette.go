@@ -0,0 +1,171 @@
+// Package cassette records SP-API interactions to files and replays them in tests.
+// Both transports are plugged in via Config.HTTPClient, e.g.
+//
+//	recorder := cassette.NewRecorder("testdata/orders.json", http.DefaultTransport)
+//	client, err := sp_api.NewClient(sp_api.Config{..., HTTPClient: &http.Client{Transport: recorder}})
+//
+//	replayer, err := cassette.Load("testdata/orders.json")
+//	client, err := sp_api.NewClient(sp_api.Config{..., HTTPClient: &http.Client{Transport: replayer}})
+//
+// Recorded interactions are redacted: access tokens, RDTs, client secrets, refresh tokens,
+// the PII fields of JSON and form-encoded bodies and the signatures of presigned URLs are replaced
+// with Redacted. Other bodies, e.g. report documents, are replaced with Redacted as a whole,
+// unless RecorderConfig.RedactBody is set, e.g. to RedactTSV(DefaultFlatFilePIIColumns...).
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Redacted replaces redacted values.
+const Redacted = "REDACTED"
+
+// Cassette contains the recorded interactions in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  url.Values  `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body
+}
+
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body
+}
+
+// Body is stored as text if it is valid UTF-8, binary bodies like compressed documents are stored base64 encoded.
+type Body struct {
+	Body       string `json:"body,omitempty"`
+	BodyBase64 []byte `json:"bodyBase64,omitempty"`
+}
+
+func newBody(content []byte) Body {
+	if utf8.Valid(content) {
+		return Body{Body: string(content)}
+	}
+	return Body{BodyBase64: content}
+}
+
+// Bytes returns the content of the body.
+func (b Body) Bytes() []byte {
+	if b.BodyBase64 != nil {
+		return b.BodyBase64
+	}
+	return []byte(b.Body)
+}
+
+// Load reads a cassette and returns a Replayer for its interactions.
+func Load(path string) (*Replayer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err := json.Unmarshal(content, c); err != nil {
+		return nil, err
+	}
+	return NewReplayer(c), nil
+}
+
+// Save writes the cassette to path, a previous file is replaced.
+func (c *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// normalizeQuery returns the query without empty values and presigned URL parameters,
+// so that optional parameters which are sent empty and signatures don't affect matching.
+func normalizeQuery(query url.Values) url.Values {
+	normalized := url.Values{}
+	for key, values := range query {
+		if isPresignParam(key) {
+			continue
+		}
+		for _, v := range values {
+			if v != "" {
+				normalized.Add(key, v)
+			}
+		}
+	}
+	return normalized
+}
+
+// queryMatches reports whether the normalized queries are equal regardless of the order of their
+// parameters. A recorded parameter which was redacted matches any value.
+func queryMatches(recorded, actual url.Values) bool {
+	recorded, actual = normalizeQuery(recorded), normalizeQuery(actual)
+	if len(recorded) != len(actual) {
+		return false
+	}
+	for key, values := range recorded {
+		actualValues, ok := actual[key]
+		if !ok {
+			return false
+		}
+		if len(values) == 1 && values[0] == Redacted {
+			continue
+		}
+		if !equalUnordered(values, actualValues) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalUnordered(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isPresignParam reports whether the query parameter belongs to the signature of a presigned URL.
+func isPresignParam(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), "x-amz-")
+}
@@ -0,0 +1,281 @@
+package cassette
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sp_api "github.com/fond-of-vertigo/amazon-sp-api"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	ordersv0 "github.com/fond-of-vertigo/amazon-sp-api/apis/orders/v0"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/spapitest"
+	"github.com/fond-of-vertigo/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// callAPI calls the operations which are recorded and replayed.
+func callAPI(t *testing.T, client *sp_api.Client) (*ordersv0.Order, string) {
+	t.Helper()
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	orders, err := client.OrdersV0API.GetOrders(&ordersv0.GetOrdersFilter{
+		CreatedAfter:   &apis.JsonTimeISO8601{Time: created},
+		MarketplaceIDs: []constants.MarketplaceID{constants.Germany},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, orders.ResponseBody.Payload.Orders, 1)
+
+	report, err := client.ReportsAPI.CreateReport(&reports.CreateReportSpecification{
+		ReportType:     reports.FBAAmazonFulfilledInventoryReport,
+		MarketplaceIDs: []constants.MarketplaceID{constants.Germany},
+	})
+	require.NoError(t, err)
+
+	var status *reports.GetReportResponse
+	for status == nil || !status.ProcessingStatus.IsDone() {
+		resp, err := client.ReportsAPI.GetReport(report.ResponseBody.ReportID)
+		require.NoError(t, err)
+		status = resp.ResponseBody
+	}
+	require.NotNil(t, status.ReportDocumentID)
+
+	doc, err := client.ReportsAPI.GetReportDocument(*status.ReportDocumentID, nil)
+	require.NoError(t, err)
+	return &orders.ResponseBody.Payload.Orders[0], doc.ResponseBody.Url
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "orders.json")
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s := spapitest.NewServer(t)
+	s.AddOrdersV0(ordersv0.Order{
+		AmazonOrderId:  "302-1234567-1234567",
+		PurchaseDate:   created,
+		LastUpdateDate: created,
+		MarketplaceId:  ptr(string(constants.Germany)),
+		BuyerInfo:      &ordersv0.BuyerInfo{BuyerEmail: ptr("buyer@example.com"), BuyerName: ptr("Jane Doe")},
+		ShippingAddress: &ordersv0.Address{
+			Name:         "Jane Doe",
+			AddressLine1: ptr("Main Street 1"),
+			City:         ptr("Berlin"),
+		},
+	})
+	s.ScriptReport(reports.FBAAmazonFulfilledInventoryReport, spapitest.ReportScript{
+		Statuses: []constants.ProcessingStatus{constants.InQueue, constants.InProgress, constants.Done},
+		Document: []byte("sku\tquantity\n"),
+	})
+	recorder := NewRecorder(path, http.DefaultTransport)
+	recordedOrder, recordedURL := callAPI(t, s.NewClient(sp_api.Config{HTTPClient: &http.Client{Transport: recorder}}))
+	assert.Equal(t, "buyer@example.com", *recordedOrder.BuyerInfo.BuyerEmail, "the caller receives the original response")
+	assert.Contains(t, recordedURL, "X-Amz-Signature=spapitest")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{
+		spapitest.AccessToken, spapitest.RefreshToken, spapitest.ClientSecret,
+		"buyer@example.com", "Jane Doe", "Main Street 1", "X-Amz-Signature=spapitest",
+	} {
+		assert.NotContains(t, string(content), secret)
+	}
+	assert.Contains(t, string(content), "Berlin")
+	assert.Len(t, recorder.Interactions(), 6, "token, getOrders, createReport, 2x getReport, getReportDocument")
+
+	replayer, err := Load(path)
+	require.NoError(t, err)
+	client, err := sp_api.NewClient(sp_api.Config{
+		ClientID:     "replay",
+		ClientSecret: "replay",
+		RefreshToken: "replay",
+		Endpoint:     "https://replay.invalid",
+		TokenURL:     "https://replay.invalid" + spapitest.TokenPath,
+		HTTPClient:   &http.Client{Transport: replayer},
+		Log:          logger.New(logger.LvlError),
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	replayedOrder, replayedURL := callAPI(t, client)
+	assert.Equal(t, recordedOrder.AmazonOrderId, replayedOrder.AmazonOrderId)
+	assert.Equal(t, Redacted, *replayedOrder.BuyerInfo.BuyerEmail)
+	assert.Equal(t, Redacted, replayedOrder.ShippingAddress.Name)
+	assert.Contains(t, replayedURL, "X-Amz-Signature="+Redacted)
+	assert.Empty(t, replayer.Unused())
+
+	_, err = client.ReportsAPI.GetReport("unknown")
+	assert.True(t, errors.Is(err, ErrInteractionNotFound), err)
+}
+
+func TestQueryMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		recorded string
+		actual   string
+		want     bool
+	}{
+		{name: "equal", recorded: "a=1&b=2", actual: "a=1&b=2", want: true},
+		{name: "different order", recorded: "b=2&a=1&a=3", actual: "a=3&a=1&b=2", want: true},
+		{name: "empty values are ignored", recorded: "a=1&b=", actual: "a=1&c=", want: true},
+		{name: "presign params are ignored", recorded: "a=1&X-Amz-Signature=REDACTED", actual: "a=1&X-Amz-Signature=abc&X-Amz-Date=20240101", want: true},
+		{name: "redacted value matches any value", recorded: "BuyerEmail=REDACTED", actual: "BuyerEmail=buyer%40example.com", want: true},
+		{name: "redacted param must be present", recorded: "BuyerEmail=REDACTED", actual: "", want: false},
+		{name: "different value", recorded: "a=1", actual: "a=2", want: false},
+		{name: "missing param", recorded: "a=1&b=2", actual: "a=1", want: false},
+		{name: "additional param", recorded: "a=1", actual: "a=1&b=2", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorded, err := url.ParseQuery(tt.recorded)
+			require.NoError(t, err)
+			actual, err := url.ParseQuery(tt.actual)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, queryMatches(recorded, actual))
+		})
+	}
+}
+
+func TestRecorder_DocumentBodies(t *testing.T) {
+	document := "order-id\tbuyer-email\tship-address-1\tsku\r\n" +
+		"302-1\tbuyer@example.com\tMain Street 1\tSKU-1\r\n" +
+		"302-2\t\tSecond Street 2\tSKU-2\r\n"
+	tests := []struct {
+		name       string
+		compress   bool
+		redactBody BodyRedactor
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "replaced without RedactBody",
+			want: Redacted,
+		},
+		{
+			name:       "PII columns redacted by RedactTSV",
+			redactBody: RedactTSV(DefaultFlatFilePIIColumns...),
+			want: "order-id\tbuyer-email\tship-address-1\tsku\r\n" +
+				"302-1\tREDACTED\tREDACTED\tSKU-1\r\n" +
+				"302-2\t\tREDACTED\tSKU-2\r\n",
+		},
+		{
+			name:       "compressed document is rejected by RedactTSV",
+			compress:   true,
+			redactBody: RedactTSV(DefaultFlatFilePIIColumns...),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := spapitest.NewServer(t)
+			s.ScriptReport(reports.FBAAmazonFulfilledInventoryReport, spapitest.ReportScript{
+				Statuses: []constants.ProcessingStatus{constants.Done},
+				Document: []byte(document),
+				Compress: tt.compress,
+			})
+			recorder := NewRecorderWithConfig(filepath.Join(t.TempDir(), "report.json"), RecorderConfig{RedactBody: tt.redactBody})
+			httpClient := &http.Client{Transport: recorder}
+			client := s.NewClient(sp_api.Config{HTTPClient: httpClient})
+
+			created, err := client.ReportsAPI.CreateReport(&reports.CreateReportSpecification{
+				ReportType:     reports.FBAAmazonFulfilledInventoryReport,
+				MarketplaceIDs: []constants.MarketplaceID{constants.Germany},
+			})
+			require.NoError(t, err)
+			report, err := client.ReportsAPI.GetReport(created.ResponseBody.ReportID)
+			require.NoError(t, err)
+			doc, err := client.ReportsAPI.GetReportDocument(*report.ResponseBody.ReportDocumentID, nil)
+			require.NoError(t, err)
+
+			resp, err := httpClient.Get(doc.ResponseBody.Url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			interactions := recorder.Interactions()
+			assert.Equal(t, tt.want, string(interactions[len(interactions)-1].Response.Bytes()))
+		})
+	}
+}
+
+func TestRecorder_FormBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"Atza|secret","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorderWithConfig(filepath.Join(t.TempDir(), "token.json"), RecorderConfig{
+		RedactBody: RedactTSV(DefaultFlatFilePIIColumns...),
+	})
+	httpClient := &http.Client{Transport: recorder}
+	resp, err := httpClient.PostForm(server.URL+spapitest.TokenPath, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {"Atzr|secret"},
+		"client_id":     {"clientID"},
+		"client_secret": {"clientSecret"},
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	interactions := recorder.Interactions()
+	require.Len(t, interactions, 1)
+	body, err := url.ParseQuery(string(interactions[0].Request.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {Redacted},
+		"client_id":     {"clientID"},
+		"client_secret": {Redacted},
+	}, body)
+}
+
+func TestRedactor(t *testing.T) {
+	r := newRedactor(DefaultPIIFields)
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "token response",
+			body: `{"access_token":"Atza|secret","refresh_token":"Atzr|secret","token_type":"bearer","expires_in":3600}`,
+			want: `{"access_token":"REDACTED","expires_in":3600,"refresh_token":"REDACTED","token_type":"bearer"}`,
+		},
+		{
+			name: "restricted data token",
+			body: `{"restrictedDataToken":"Atz.sprdt|secret","expiresIn":3600}`,
+			want: `{"expiresIn":3600,"restrictedDataToken":"REDACTED"}`,
+		},
+		{
+			name: "nested PII",
+			body: `{"orders":[{"buyer":{"buyerEmail":"a@b.c","buyerName":"Jane"},"recipient":{"deliveryAddress":{"name":"Jane","city":"Berlin"}}}]}`,
+			want: `{"orders":[{"buyer":{"buyerEmail":"REDACTED","buyerName":"REDACTED"},"recipient":{"deliveryAddress":{"city":"Berlin","name":"REDACTED"}}}]}`,
+		},
+		{
+			name: "presigned URL",
+			body: `{"url":"https://bucket.s3.amazonaws.com/doc?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Credential=AKIA&X-Amz-Signature=abc"}`,
+			want: `{"url":"https://bucket.s3.amazonaws.com/doc?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Credential=REDACTED&X-Amz-Signature=REDACTED"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.json([]byte(tt.body))
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
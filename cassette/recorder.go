@@ -0,0 +1,138 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// BodyRedactor redacts a body which is neither JSON nor form-encoded, e.g. a downloaded report document or an
+// uploaded feed. contentType is the Content-Type header of the request or response.
+type BodyRedactor func(body []byte, contentType string) ([]byte, error)
+
+type RecorderConfig struct {
+	// Next sends the requests, defaults to http.DefaultTransport.
+	Next http.RoundTripper
+	// PIIFields are the JSON fields which are redacted in addition to credentials, defaults to DefaultPIIFields.
+	PIIFields []string
+	// RedactBody opts in to recording bodies which are neither JSON nor form-encoded. Without it they are replaced
+	// with Redacted, as documents like order reports contain buyer names and addresses.
+	// RedactTSV redacts the PII columns of flat file reports.
+	RedactBody BodyRedactor
+}
+
+// Recorder is a http.RoundTripper which sends requests via the next transport and records the
+// redacted interactions. The cassette is saved after every interaction, so no explicit save is needed.
+type Recorder struct {
+	path       string
+	next       http.RoundTripper
+	redactor   *redactor
+	redactBody BodyRedactor
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder which writes to the cassette at path, see RecorderConfig for the defaults.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	return NewRecorderWithConfig(path, RecorderConfig{Next: next})
+}
+
+// NewRecorderWithConfig returns a Recorder which writes to the cassette at path.
+func NewRecorderWithConfig(path string, config RecorderConfig) *Recorder {
+	if config.Next == nil {
+		config.Next = http.DefaultTransport
+	}
+	if config.PIIFields == nil {
+		config.PIIFields = DefaultPIIFields
+	}
+	return &Recorder{
+		path:       path,
+		next:       config.Next,
+		redactor:   newRedactor(config.PIIFields),
+		redactBody: config.RedactBody,
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		content, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestBody = content
+		req.Body = io.NopCloser(bytes.NewReader(content))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	redactedRequestBody, err := r.body(requestBody, req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("redacting request body of %s %s failed: %w", req.Method, req.URL.Path, err)
+	}
+	redactedResponseBody, err := r.body(responseBody, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("redacting response body of %s %s failed: %w", req.Method, req.URL.Path, err)
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  r.redactor.query(req.URL.Query()),
+			Header: r.redactor.header(req.Header),
+			Body:   newBody(redactedRequestBody),
+		},
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: r.redactor.header(resp.Header),
+			Body:   newBody(redactedResponseBody),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.cassette.Save(r.path); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// body redacts JSON and form-encoded bodies and passes other bodies to redactBody, or replaces
+// them with Redacted.
+func (r *Recorder) body(content []byte, contentType string) ([]byte, error) {
+	if len(content) == 0 {
+		return content, nil
+	}
+	if redacted, ok := r.redactor.json(content); ok {
+		return redacted, nil
+	}
+	if redacted, ok := r.redactor.form(content, contentType); ok {
+		return redacted, nil
+	}
+	if r.redactBody == nil {
+		return []byte(Redacted), nil
+	}
+	return r.redactBody(content, contentType)
+}
+
+// Interactions returns the interactions recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction{}, r.cassette.Interactions...)
+}
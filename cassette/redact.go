@@ -0,0 +1,239 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// redactedHeaders contain credentials and are redacted in requests and responses.
+var redactedHeaders = []string{
+	constants.AccessTokenHeader,
+	"Authorization",
+	"X-Amz-Security-Token",
+}
+
+// secretFields are the JSON fields of the LWA and Tokens API which contain credentials.
+var secretFields = []string{
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"restrictedDataToken",
+}
+
+// DefaultPIIFields are the JSON fields of order and address models which contain personally
+// identifiable information. Field names are matched case-insensitively.
+var DefaultPIIFields = []string{
+	"Name",
+	"BuyerName",
+	"BuyerEmail",
+	"BuyerCompanyName",
+	"BuyerPurchaseOrderNumber",
+	"PurchaseOrderNumber",
+	"CompanyName",
+	"CompanyLegalName",
+	"Email",
+	"Phone",
+	"AddressLine1",
+	"AddressLine2",
+	"AddressLine3",
+	"StreetName",
+	"AddressInstruction",
+	"GiftMessage",
+	"GiftMessageText",
+}
+
+// redactor replaces credentials, PII and presigned URL signatures with Redacted.
+type redactor struct {
+	fields map[string]bool
+}
+
+func newRedactor(piiFields []string) *redactor {
+	r := &redactor{fields: map[string]bool{}}
+	for _, field := range append(append([]string{}, secretFields...), piiFields...) {
+		r.fields[strings.ToLower(field)] = true
+	}
+	return r
+}
+
+func (r *redactor) header(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Redacted)
+		}
+	}
+	return redacted
+}
+
+func (r *redactor) query(query url.Values) url.Values {
+	if len(query) == 0 {
+		return nil
+	}
+	redacted := url.Values{}
+	for key, values := range query {
+		if isSignatureParam(key) || r.fields[strings.ToLower(key)] {
+			redacted[key] = []string{Redacted}
+			continue
+		}
+		redacted[key] = append([]string{}, values...)
+	}
+	return redacted
+}
+
+// form redacts an application/x-www-form-urlencoded body, e.g. an LWA token request with the
+// refresh token and client secret. It returns false if the body is not form-encoded.
+func (r *redactor) form(content []byte, contentType string) ([]byte, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return nil, false
+	}
+	values, err := url.ParseQuery(string(content))
+	if err != nil {
+		return nil, false
+	}
+	return []byte(r.query(values).Encode()), true
+}
+
+// json redacts a JSON body. It returns false if the body is not JSON.
+func (r *redactor) json(content []byte) ([]byte, bool) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, false
+	}
+	redacted := &bytes.Buffer{}
+	encoder := json.NewEncoder(redacted)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.value(value)); err != nil {
+		return nil, false
+	}
+	return bytes.TrimSuffix(redacted.Bytes(), []byte("\n")), true
+}
+
+func (r *redactor) value(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if r.fields[strings.ToLower(key)] && field != nil {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = r.value(field)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+		return v
+	case string:
+		return r.url(v)
+	default:
+		return v
+	}
+}
+
+// url redacts the signature of a presigned URL, other strings are returned unchanged.
+func (r *redactor) url(s string) string {
+	if !strings.HasPrefix(s, "https://") && !strings.HasPrefix(s, "http://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+	query := u.Query()
+	signed := false
+	for key := range query {
+		if isSignatureParam(key) {
+			query.Set(key, Redacted)
+			signed = true
+		}
+	}
+	if !signed {
+		return s
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// isSignatureParam reports whether the query parameter of a presigned URL contains credentials.
+func isSignatureParam(key string) bool {
+	switch strings.ToLower(key) {
+	case "x-amz-signature", "x-amz-credential", "x-amz-security-token":
+		return true
+	}
+	return false
+}
+
+// DefaultFlatFilePIIColumns are the columns of flat file order and shipment reports which contain
+// personally identifiable information. Column names are matched case-insensitively.
+var DefaultFlatFilePIIColumns = []string{
+	"buyer-email",
+	"buyer-name",
+	"buyer-phone-number",
+	"buyer-company-name",
+	"recipient-name",
+	"ship-address-1",
+	"ship-address-2",
+	"ship-address-3",
+	"ship-phone-number",
+	"bill-name",
+	"bill-address-1",
+	"bill-address-2",
+	"bill-address-3",
+	"purchase-order-number",
+	"gift-message-text",
+}
+
+// RedactTSV returns a BodyRedactor for tab-separated flat file documents with a header row,
+// which redacts the values of the given columns. It fails for other bodies, e.g. compressed
+// documents, so that they are never recorded unredacted.
+func RedactTSV(columns ...string) BodyRedactor {
+	redacted := map[string]bool{}
+	for _, column := range columns {
+		redacted[strings.ToLower(column)] = true
+	}
+	return func(body []byte, _ string) ([]byte, error) {
+		if !utf8.Valid(body) {
+			return nil, errors.New("body is not a tab-separated document")
+		}
+		lines := strings.Split(string(body), "\n")
+		header := strings.Split(strings.TrimSuffix(lines[0], "\r"), "\t")
+		if len(header) < 2 {
+			return nil, errors.New("body is not a tab-separated document")
+		}
+		var indexes []int
+		for i, column := range header {
+			if redacted[strings.ToLower(strings.TrimSpace(column))] {
+				indexes = append(indexes, i)
+			}
+		}
+		for n := 1; n < len(lines); n++ {
+			line, cr := strings.CutSuffix(lines[n], "\r")
+			fields := strings.Split(line, "\t")
+			for _, i := range indexes {
+				if i < len(fields) && fields[i] != "" {
+					fields[i] = Redacted
+				}
+			}
+			lines[n] = strings.Join(fields, "\t")
+			if cr {
+				lines[n] += "\r"
+			}
+		}
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+}
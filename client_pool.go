@@ -0,0 +1,396 @@
+package sp_api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/applications"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+	"github.com/fond-of-vertigo/logger"
+)
+
+// ErrClientPoolClosed is returned by ClientPool.Get after the pool has been closed.
+var ErrClientPoolClosed = errors.New("client pool is closed")
+
+// SellerCredentials are the seller specific parts of a Config.
+type SellerCredentials struct {
+	RefreshToken string
+	// Endpoint overrides ClientPoolConfig.Endpoint for this seller if set.
+	Endpoint constants.Endpoint
+	// Marketplace determines the endpoint of the seller if Endpoint is not set.
+	Marketplace constants.MarketplaceID
+}
+
+// SellerCredentialsProvider returns the credentials of a seller, e.g. from a database.
+// It is called whenever a ClientPool creates a client for a seller.
+type SellerCredentialsProvider interface {
+	GetSellerCredentials(sellerID string) (*SellerCredentials, error)
+}
+
+// SellerCredentialsProviderFunc adapts a function to the SellerCredentialsProvider interface.
+type SellerCredentialsProviderFunc func(sellerID string) (*SellerCredentials, error)
+
+func (f SellerCredentialsProviderFunc) GetSellerCredentials(sellerID string) (*SellerCredentials, error) {
+	return f(sellerID)
+}
+
+type ClientPoolConfig struct {
+	ClientID     string
+	ClientSecret string
+	// ClientSecretRotator replaces ClientSecret if set, so that rotated secrets are used by all clients.
+	ClientSecretRotator *applications.ClientSecretRotator
+	// Endpoint is used for all sellers without their own endpoint.
+	Endpoint            constants.Endpoint
+	CredentialsProvider SellerCredentialsProvider
+	// Log is passed to the Config of every seller. Defaults to a logger which discards all messages.
+	Log logger.Logger
+	// Transport is shared by the clients of all sellers. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// Timeout is the timeout of a single HTTP request, zero means no timeout.
+	Timeout time.Duration
+	// IdleTimeout is the duration after which the client of an unused seller is closed.
+	// Zero disables the eviction of idle clients.
+	IdleTimeout time.Duration
+	// AutoRestrictedDataToken is passed to the Config of every seller.
+	AutoRestrictedDataToken bool
+	// Sandbox is passed to the Config of every seller.
+	Sandbox bool
+}
+
+// RateLimitState describes the throttling of a seller as observed by a ClientPool.
+type RateLimitState struct {
+	// Requests is the number of HTTP requests sent for the seller.
+	Requests int
+	// TooManyRequests is the number of responses with HTTP 429.
+	TooManyRequests int
+	// LastThrottled is the time of the last response with HTTP 429.
+	LastThrottled time.Time
+	// Limit is the value of the x-amzn-RateLimit-Limit header of the last response
+	// which contained it, i.e. the allowed requests per second of the last operation.
+	Limit float64
+}
+
+// ClientPool manages the clients of many sellers which share the same application credentials.
+// Clients are created lazily on first use and closed again after IdleTimeout, which also stops
+// their token updater. Clients which are acquired are not closed before they are released.
+// All clients share the same HTTP transport.
+type ClientPool struct {
+	config      ClientPoolConfig
+	transport   http.RoundTripper
+	newClient   func(config Config) (*Client, error)
+	closeClient func(client *Client)
+	now         func() time.Time
+
+	mu      sync.Mutex
+	sellers map[string]*pooledClient
+	closed  bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+type pooledClient struct {
+	once     sync.Once
+	client   *Client
+	err      error
+	lastUsed time.Time
+	// refs is the number of unreleased Acquire calls, removed is set once the entry was
+	// evicted or the pool was closed. Both are guarded by ClientPool.mu.
+	refs    int
+	removed bool
+
+	rateLimitMu sync.Mutex
+	rateLimit   RateLimitState
+}
+
+// NewClientPool creates a ClientPool. If IdleTimeout is set, a goroutine evicts idle clients
+// until the pool is closed.
+func NewClientPool(config ClientPoolConfig) (*ClientPool, error) {
+	if config.CredentialsProvider == nil {
+		return nil, errors.New("credentialsProvider is required")
+	}
+
+	transport := config.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if config.Log == nil {
+		config.Log = logger.NewWithWriter(logger.LvlError, io.Discard)
+	}
+
+	p := &ClientPool{
+		config:      config,
+		transport:   transport,
+		newClient:   NewClient,
+		closeClient: (*Client).Close,
+		now:         time.Now,
+		sellers:     map[string]*pooledClient{},
+		done:        make(chan struct{}),
+	}
+	if config.IdleTimeout > 0 {
+		p.wg.Add(1)
+		go p.evictIdleInBackground()
+	}
+	return p, nil
+}
+
+// Get returns the client of the seller and creates it if necessary. The returned client must not
+// be stored, call Get for every unit of work instead, as idle clients are closed by the pool.
+// Use Acquire for work which holds the client longer than IdleTimeout, e.g. polling a report.
+func (p *ClientPool) Get(sellerID string) (*Client, error) {
+	client, _, err := p.get(sellerID, false)
+	return client, err
+}
+
+// Acquire returns the client of the seller like Get and marks it as in use until release is called.
+// Clients in use are not evicted as idle, and Evict and Close only close them after their release.
+// Calling release more than once has no effect.
+func (p *ClientPool) Acquire(sellerID string) (client *Client, release func(), err error) {
+	return p.get(sellerID, true)
+}
+
+func (p *ClientPool) get(sellerID string, acquire bool) (*Client, func(), error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, nil, ErrClientPoolClosed
+	}
+	entry, ok := p.sellers[sellerID]
+	if !ok {
+		entry = &pooledClient{}
+		p.sellers[sellerID] = entry
+	}
+	entry.lastUsed = p.now()
+	if acquire {
+		entry.refs++
+	}
+	p.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.client, entry.err = p.createClient(sellerID, entry)
+	})
+	if entry.err != nil {
+		p.mu.Lock()
+		if p.sellers[sellerID] == entry {
+			delete(p.sellers, sellerID)
+		}
+		if acquire {
+			entry.refs--
+		}
+		p.mu.Unlock()
+		return nil, nil, entry.err
+	}
+	if !acquire {
+		return entry.client, nil, nil
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { p.release(entry) })
+	}
+	return entry.client, release, nil
+}
+
+func (p *ClientPool) release(entry *pooledClient) {
+	p.mu.Lock()
+	entry.refs--
+	entry.lastUsed = p.now()
+	closeNow := entry.removed && entry.refs == 0
+	p.mu.Unlock()
+
+	if closeNow {
+		p.closeEntry(entry)
+	}
+}
+
+func (p *ClientPool) createClient(sellerID string, entry *pooledClient) (*Client, error) {
+	credentials, err := p.config.CredentialsProvider.GetSellerCredentials(sellerID)
+	if err != nil {
+		return nil, err
+	}
+	if credentials == nil || credentials.RefreshToken == "" {
+		return nil, errors.New("no refresh token found for seller " + sellerID)
+	}
+
+	endpoint := credentials.Endpoint
+	if endpoint == "" && credentials.Marketplace == "" {
+		endpoint = p.config.Endpoint
+	}
+
+	var credentialsProvider httpx.CredentialsProvider
+	if p.config.ClientSecretRotator != nil {
+		credentialsProvider = p.config.ClientSecretRotator.CredentialsProvider(credentials.RefreshToken)
+	}
+
+	return p.newClient(Config{
+		ClientID:            p.config.ClientID,
+		ClientSecret:        p.config.ClientSecret,
+		RefreshToken:        credentials.RefreshToken,
+		CredentialsProvider: credentialsProvider,
+		Endpoint:            endpoint,
+		Marketplace:         credentials.Marketplace,
+		Log:                 p.config.Log,
+		HTTPClient: &http.Client{
+			Transport: &rateLimitTransport{next: p.transport, entry: entry, now: p.now},
+			Timeout:   p.config.Timeout,
+		},
+		AutoRestrictedDataToken: p.config.AutoRestrictedDataToken,
+		Sandbox:                 p.config.Sandbox,
+	})
+}
+
+// RateLimitState returns the observed throttling of the seller. The second return value is false
+// if the pool has no client for the seller.
+func (p *ClientPool) RateLimitState(sellerID string) (RateLimitState, bool) {
+	p.mu.Lock()
+	entry, ok := p.sellers[sellerID]
+	p.mu.Unlock()
+	if !ok {
+		return RateLimitState{}, false
+	}
+
+	entry.rateLimitMu.Lock()
+	defer entry.rateLimitMu.Unlock()
+	return entry.rateLimit, true
+}
+
+// Sellers returns the IDs of all sellers which currently have a client.
+func (p *ClientPool) Sellers() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sellerIDs := make([]string, 0, len(p.sellers))
+	for sellerID := range p.sellers {
+		sellerIDs = append(sellerIDs, sellerID)
+	}
+	return sellerIDs
+}
+
+// Evict closes the client of the seller, e.g. after its refresh token was revoked, or after its
+// release if it is acquired. The next Get creates a new client.
+func (p *ClientPool) Evict(sellerID string) {
+	p.mu.Lock()
+	entry, ok := p.sellers[sellerID]
+	delete(p.sellers, sellerID)
+	closeNow := ok && p.remove(entry)
+	p.mu.Unlock()
+
+	if closeNow {
+		p.closeEntry(entry)
+	}
+}
+
+// EvictIdle closes the clients of all sellers which are not acquired and were not used within
+// IdleTimeout and returns their number.
+func (p *ClientPool) EvictIdle() int {
+	if p.config.IdleTimeout <= 0 {
+		return 0
+	}
+
+	deadline := p.now().Add(-p.config.IdleTimeout)
+	var evicted []*pooledClient
+	p.mu.Lock()
+	for sellerID, entry := range p.sellers {
+		if entry.refs == 0 && entry.lastUsed.Before(deadline) {
+			p.remove(entry)
+			evicted = append(evicted, entry)
+			delete(p.sellers, sellerID)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, entry := range evicted {
+		p.closeEntry(entry)
+	}
+	return len(evicted)
+}
+
+// Close stops the eviction goroutine and closes the clients of all sellers, acquired clients
+// after their release. Calling Close more than once has no effect.
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	var entries []*pooledClient
+	for _, entry := range p.sellers {
+		if p.remove(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	p.sellers = map[string]*pooledClient{}
+	p.mu.Unlock()
+
+	close(p.done)
+	p.wg.Wait()
+	for _, entry := range entries {
+		p.closeEntry(entry)
+	}
+}
+
+// remove marks the entry as removed from the pool and reports whether it can be closed now,
+// otherwise its last release closes it. The caller must hold p.mu.
+func (p *ClientPool) remove(entry *pooledClient) bool {
+	entry.removed = true
+	return entry.refs == 0
+}
+
+func (p *ClientPool) closeEntry(entry *pooledClient) {
+	// waits for a running creation and prevents a later one
+	entry.once.Do(func() {
+		entry.err = ErrClientPoolClosed
+	})
+	if entry.client != nil {
+		p.closeClient(entry.client)
+	}
+}
+
+func (p *ClientPool) evictIdleInBackground() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.IdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			if n := p.EvictIdle(); n > 0 {
+				p.config.Log.Debugf("Evicted %d idle seller clients.", n)
+			}
+		}
+	}
+}
+
+// rateLimitTransport records the rate limit state of a seller from its responses.
+type rateLimitTransport struct {
+	next  http.RoundTripper
+	entry *pooledClient
+	now   func() time.Time
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	t.entry.rateLimitMu.Lock()
+	defer t.entry.rateLimitMu.Unlock()
+	state := &t.entry.rateLimit
+	state.Requests++
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		state.TooManyRequests++
+		state.LastThrottled = t.now()
+	}
+	if limit, err := strconv.ParseFloat(resp.Header.Get(constants.RateLimitHeader), 64); err == nil {
+		state.Limit = limit
+	}
+	return resp, nil
+}
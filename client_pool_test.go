@@ -0,0 +1,251 @@
+package sp_api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/applications"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestClientPool(t *testing.T, idleTimeout time.Duration) (p *ClientPool, now *time.Time, configs map[string]Config, closed map[*Client]int) {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = &start
+	configs = map[string]Config{}
+	closed = map[*Client]int{}
+
+	p, err := NewClientPool(ClientPoolConfig{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		Endpoint:     constants.Europe,
+		CredentialsProvider: SellerCredentialsProviderFunc(func(sellerID string) (*SellerCredentials, error) {
+			switch sellerID {
+			case "unknown":
+				return nil, errors.New("unknown seller")
+			case "us":
+				return &SellerCredentials{RefreshToken: "refresh-us", Endpoint: constants.NorthAmerica}, nil
+			}
+			return &SellerCredentials{RefreshToken: "refresh-" + sellerID}, nil
+		}),
+	})
+	require.NoError(t, err)
+
+	p.config.IdleTimeout = idleTimeout
+	p.now = func() time.Time { return *now }
+	p.newClient = func(config Config) (*Client, error) {
+		configs[config.RefreshToken] = config
+		return &Client{}, nil
+	}
+	p.closeClient = func(client *Client) {
+		closed[client]++
+	}
+	return p, now, configs, closed
+}
+
+func TestClientPool_Get(t *testing.T) {
+	p, _, configs, _ := newTestClientPool(t, 0)
+
+	first, err := p.Get("a")
+	require.NoError(t, err)
+	second, err := p.Get("a")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, err = p.Get("us")
+	require.NoError(t, err)
+	assert.Equal(t, constants.Europe, configs["refresh-a"].Endpoint)
+	assert.Equal(t, constants.NorthAmerica, configs["refresh-us"].Endpoint)
+	assert.Equal(t, "client-id", configs["refresh-a"].ClientID)
+
+	_, err = p.Get("unknown")
+	assert.Error(t, err)
+
+	sellers := p.Sellers()
+	sort.Strings(sellers)
+	assert.Equal(t, []string{"a", "us"}, sellers)
+}
+
+func TestClientPool_EvictIdle(t *testing.T) {
+	p, now, _, closed := newTestClientPool(t, time.Hour)
+
+	idle, err := p.Get("idle")
+	require.NoError(t, err)
+	*now = now.Add(50 * time.Minute)
+	active, err := p.Get("active")
+	require.NoError(t, err)
+	*now = now.Add(20 * time.Minute)
+
+	assert.Equal(t, 1, p.EvictIdle())
+	assert.Equal(t, 1, closed[idle])
+	assert.Equal(t, 0, closed[active])
+	assert.Equal(t, []string{"active"}, p.Sellers())
+
+	recreated, err := p.Get("idle")
+	require.NoError(t, err)
+	assert.NotSame(t, idle, recreated)
+}
+
+func TestClientPool_Close(t *testing.T) {
+	p, _, _, closed := newTestClientPool(t, 0)
+
+	client, err := p.Get("a")
+	require.NoError(t, err)
+
+	p.Close()
+	p.Close()
+	assert.Equal(t, 1, closed[client])
+
+	_, err = p.Get("a")
+	assert.ErrorIs(t, err, ErrClientPoolClosed)
+}
+
+func TestClientPool_RateLimitState(t *testing.T) {
+	p, now, configs, _ := newTestClientPool(t, 0)
+
+	_, ok := p.RateLimitState("a")
+	assert.False(t, ok)
+
+	statusCodes := []int{http.StatusOK, http.StatusTooManyRequests}
+	p.transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: statusCodes[0], Header: http.Header{}}
+		resp.Header.Set(constants.RateLimitHeader, "0.0167")
+		statusCodes = statusCodes[1:]
+		return resp, nil
+	})
+	_, err := p.Get("a")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		_, err = configs["refresh-a"].HTTPClient.Transport.RoundTrip(req)
+		require.NoError(t, err)
+	}
+
+	state, ok := p.RateLimitState("a")
+	assert.True(t, ok)
+	assert.Equal(t, RateLimitState{
+		Requests:        2,
+		TooManyRequests: 1,
+		LastThrottled:   *now,
+		Limit:           0.0167,
+	}, state)
+}
+
+func TestClientPool_Acquire(t *testing.T) {
+	p, now, _, closed := newTestClientPool(t, time.Hour)
+
+	polling, release, err := p.Acquire("polling")
+	require.NoError(t, err)
+	*now = now.Add(2 * time.Hour)
+
+	assert.Equal(t, 0, p.EvictIdle(), "acquired clients are not idle")
+	same, err := p.Get("polling")
+	require.NoError(t, err)
+	assert.Same(t, polling, same)
+
+	p.Evict("polling")
+	assert.Equal(t, 0, closed[polling], "evicted clients are closed after their release")
+	assert.Empty(t, p.Sellers())
+
+	release()
+	release()
+	assert.Equal(t, 1, closed[polling])
+
+	released, release, err := p.Acquire("released")
+	require.NoError(t, err)
+	release()
+	*now = now.Add(2 * time.Hour)
+	assert.Equal(t, 1, p.EvictIdle())
+	assert.Equal(t, 1, closed[released])
+}
+
+func TestClientPool_CloseWithAcquiredClient(t *testing.T) {
+	p, _, _, closed := newTestClientPool(t, 0)
+
+	client, release, err := p.Acquire("a")
+	require.NoError(t, err)
+
+	p.Close()
+	assert.Equal(t, 0, closed[client])
+	release()
+	assert.Equal(t, 1, closed[client])
+}
+
+func TestNewClientPool_DefaultsLog(t *testing.T) {
+	p, _, configs, _ := newTestClientPool(t, 0)
+
+	_, err := p.Get("a")
+	require.NoError(t, err)
+	assert.NotNil(t, configs["refresh-a"].Log)
+}
+
+func TestClientPool_ClientSecretRotation(t *testing.T) {
+	var mu sync.Mutex
+	var secrets []string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		secrets = append(secrets, body["client_secret"])
+		// the first token expires immediately, so that the client requests the next one
+		expiresIn := 3600
+		if len(secrets) == 1 {
+			expiresIn = 1
+		}
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(httpx.AccessTokenResponse{AccessToken: "access-token", ExpiresIn: expiresIn})
+	}))
+	defer tokenServer.Close()
+
+	rotator, err := applications.NewClientSecretRotator(applications.ClientSecretRotatorConfig{
+		ClientID:     "client-id",
+		ClientSecret: "old-secret",
+	})
+	require.NoError(t, err)
+	p, err := NewClientPool(ClientPoolConfig{
+		ClientID:            "client-id",
+		Endpoint:            constants.Europe,
+		ClientSecretRotator: rotator,
+		CredentialsProvider: SellerCredentialsProviderFunc(func(sellerID string) (*SellerCredentials, error) {
+			return &SellerCredentials{RefreshToken: "refresh-" + sellerID}, nil
+		}),
+	})
+	require.NoError(t, err)
+	defer p.Close()
+	p.newClient = func(config Config) (*Client, error) {
+		config.TokenURL = tokenServer.URL
+		return NewClient(config)
+	}
+
+	_, err = p.Get("a")
+	require.NoError(t, err)
+	require.NoError(t, rotator.SetNewSecret(applications.ApplicationOAuthClientNewSecret{
+		ClientID:        "client-id",
+		NewClientSecret: "new-secret",
+	}))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(secrets) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"old-secret", "new-secret"}, secrets[:2])
+}
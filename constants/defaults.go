@@ -14,4 +14,25 @@ const (
 
 	//DefaultTokenUpdaterBackoffTime is the default backoff time for the token updater when a request fails
 	DefaultTokenUpdaterBackoffTime time.Duration = 15 * time.Second
+	// DefaultTokenUpdaterInitialAttempts is the default number of attempts for the first token request
+	DefaultTokenUpdaterInitialAttempts int = 3
+	// DefaultTokenUpdaterInitialBackoffTime is the wait time after the first failed attempt of the first
+	// token request, it doubles with every further attempt
+	DefaultTokenUpdaterInitialBackoffTime time.Duration = 1 * time.Second
+	// MinOnDemandTokenRefreshInterval is the minimum age of an access token before it is refreshed because
+	// of a HTTP 401 or 403 response, so that requests without permission do not flood LWA
+	MinOnDemandTokenRefreshInterval time.Duration = 30 * time.Second
+	// TokenStoreLockTimeout is the maximum time a process holds the refresh lock of a shared token store
+	// and the maximum time other processes wait for the refreshed token
+	TokenStoreLockTimeout time.Duration = 10 * time.Second
+
+	// MinBeforeFilterAge is the minimum distance to now for "before" filters like lastUpdatedBefore,
+	// as the data of the last two minutes is not yet complete
+	MinBeforeFilterAge time.Duration = 2 * time.Minute
+	// DefaultOrderSyncOverlap is the default time window which is searched again on every order sync
+	// to catch orders which became visible with a delay
+	DefaultOrderSyncOverlap time.Duration = 5 * time.Minute
+	// MaxFinancialEventsRange is the maximum time range between postedAfter and postedBefore
+	// accepted by the listFinancialEvents operation
+	MaxFinancialEventsRange time.Duration = 180 * 24 * time.Hour
 )
@@ -10,12 +10,22 @@ type MarketplaceID string
 type Region string
 type Endpoint string
 
+// GrantlessScope is the scope of an access token for grantless operations.
+type GrantlessScope string
+
 const (
 	AccessTokenHeader = "X-Amz-Access-Token"
 	RateLimitHeader   = "x-amzn-RateLimit-Limit"
 	ServiceExecuteAPI = "execute-api"
 )
 
+const (
+	// ScopeNotifications is the scope for grantless operations of the notifications API
+	ScopeNotifications GrantlessScope = "sellingpartnerapi::notifications"
+	// ScopeClientCredentialRotation is the scope for the rotation of the client secret
+	ScopeClientCredentialRotation GrantlessScope = "sellingpartnerapi::client_credential:rotation"
+)
+
 const (
 	Done       ProcessingStatus = "DONE"
 	Cancelled  ProcessingStatus = "CANCELLED"
@@ -27,6 +37,7 @@ const (
 	UnitedStatesOfAmerica MarketplaceID = "ATVPDKIKX0DER"
 	Mexico                MarketplaceID = "A1AM78C64UM0Y8"
 	Brazil                MarketplaceID = "A2Q3Y263D00KWC"
+	Ireland               MarketplaceID = "A28R8C7NBKEWEA"
 	Spain                 MarketplaceID = "A1RKKUPIHCS9HS"
 	UnitedKingdom         MarketplaceID = "A1F83G8C2ARO7P"
 	France                MarketplaceID = "A13V1IB3VIYZZH"
@@ -35,6 +46,7 @@ const (
 	Germany               MarketplaceID = "A1PA6795UKMFR9"
 	Italy                 MarketplaceID = "APJ6JRA9NG5V4"
 	Sweden                MarketplaceID = "A2NODRKZP88ZB9"
+	SouthAfrica           MarketplaceID = "AE08WJ6YKNBMC"
 	Poland                MarketplaceID = "A1C3SOZRARQ6R3"
 	Egypt                 MarketplaceID = "ARBP9OOSHTCHU"
 	Turkey                MarketplaceID = "A33AVAJ2PDY3EV"
@@ -52,4 +64,29 @@ const (
 	NorthAmerica Endpoint = "https://sellingpartnerapi-na.amazon.com"
 	Europe       Endpoint = "https://sellingpartnerapi-eu.amazon.com"
 	FarEast      Endpoint = "https://sellingpartnerapi-fe.amazon.com"
+
+	// The sandbox endpoints return canned responses for the documented static sandbox requests and
+	// serve the dynamic sandbox of APIs which support it.
+	SandboxNorthAmerica Endpoint = "https://sandbox.sellingpartnerapi-na.amazon.com"
+	SandboxEurope       Endpoint = "https://sandbox.sellingpartnerapi-eu.amazon.com"
+	SandboxFarEast      Endpoint = "https://sandbox.sellingpartnerapi-fe.amazon.com"
 )
+
+// Sandbox returns the sandbox endpoint of a production endpoint. Sandbox endpoints are returned as is,
+// the second return value is false for unknown endpoints.
+func (e Endpoint) Sandbox() (Endpoint, bool) {
+	switch e {
+	case NorthAmerica, SandboxNorthAmerica:
+		return SandboxNorthAmerica, true
+	case Europe, SandboxEurope:
+		return SandboxEurope, true
+	case FarEast, SandboxFarEast:
+		return SandboxFarEast, true
+	}
+	return e, false
+}
+
+// IsSandbox reports whether the endpoint is a sandbox endpoint.
+func (e Endpoint) IsSandbox() bool {
+	return e == SandboxNorthAmerica || e == SandboxEurope || e == SandboxFarEast
+}
@@ -0,0 +1,104 @@
+package constants
+
+import (
+	"strings"
+	"time"
+)
+
+// Marketplace describes an Amazon marketplace and the SP-API region which serves it.
+type Marketplace struct {
+	ID   MarketplaceID
+	Name string
+	// CountryCode is the ISO 3166-1 alpha-2 code, e.g. GB for the United Kingdom.
+	CountryCode string
+	// CurrencyCode is the ISO 4217 code of the default currency.
+	CurrencyCode string
+	// LanguageTag is the default locale of the marketplace, e.g. de_DE.
+	LanguageTag string
+	// Domain is the domain of the retail website, e.g. amazon.de.
+	Domain string
+	// TimeZone is the IANA time zone of the marketplace.
+	TimeZone string
+	Region   Region
+	Endpoint Endpoint
+}
+
+// Location loads the time zone of the marketplace.
+func (m Marketplace) Location() (*time.Location, error) {
+	return time.LoadLocation(m.TimeZone)
+}
+
+var marketplaces = []Marketplace{
+	{ID: Canada, Name: "Canada", CountryCode: "CA", CurrencyCode: "CAD", LanguageTag: "en_CA", Domain: "amazon.ca", TimeZone: "America/Toronto", Region: USEast, Endpoint: NorthAmerica},
+	{ID: UnitedStatesOfAmerica, Name: "United States of America", CountryCode: "US", CurrencyCode: "USD", LanguageTag: "en_US", Domain: "amazon.com", TimeZone: "America/Los_Angeles", Region: USEast, Endpoint: NorthAmerica},
+	{ID: Mexico, Name: "Mexico", CountryCode: "MX", CurrencyCode: "MXN", LanguageTag: "es_MX", Domain: "amazon.com.mx", TimeZone: "America/Mexico_City", Region: USEast, Endpoint: NorthAmerica},
+	{ID: Brazil, Name: "Brazil", CountryCode: "BR", CurrencyCode: "BRL", LanguageTag: "pt_BR", Domain: "amazon.com.br", TimeZone: "America/Sao_Paulo", Region: USEast, Endpoint: NorthAmerica},
+	{ID: Ireland, Name: "Ireland", CountryCode: "IE", CurrencyCode: "EUR", LanguageTag: "en_IE", Domain: "amazon.ie", TimeZone: "Europe/Dublin", Region: EUWest, Endpoint: Europe},
+	{ID: Spain, Name: "Spain", CountryCode: "ES", CurrencyCode: "EUR", LanguageTag: "es_ES", Domain: "amazon.es", TimeZone: "Europe/Madrid", Region: EUWest, Endpoint: Europe},
+	{ID: UnitedKingdom, Name: "United Kingdom", CountryCode: "GB", CurrencyCode: "GBP", LanguageTag: "en_GB", Domain: "amazon.co.uk", TimeZone: "Europe/London", Region: EUWest, Endpoint: Europe},
+	{ID: France, Name: "France", CountryCode: "FR", CurrencyCode: "EUR", LanguageTag: "fr_FR", Domain: "amazon.fr", TimeZone: "Europe/Paris", Region: EUWest, Endpoint: Europe},
+	{ID: Belgium, Name: "Belgium", CountryCode: "BE", CurrencyCode: "EUR", LanguageTag: "fr_BE", Domain: "amazon.com.be", TimeZone: "Europe/Brussels", Region: EUWest, Endpoint: Europe},
+	{ID: Netherlands, Name: "Netherlands", CountryCode: "NL", CurrencyCode: "EUR", LanguageTag: "nl_NL", Domain: "amazon.nl", TimeZone: "Europe/Amsterdam", Region: EUWest, Endpoint: Europe},
+	{ID: Germany, Name: "Germany", CountryCode: "DE", CurrencyCode: "EUR", LanguageTag: "de_DE", Domain: "amazon.de", TimeZone: "Europe/Berlin", Region: EUWest, Endpoint: Europe},
+	{ID: Italy, Name: "Italy", CountryCode: "IT", CurrencyCode: "EUR", LanguageTag: "it_IT", Domain: "amazon.it", TimeZone: "Europe/Rome", Region: EUWest, Endpoint: Europe},
+	{ID: Sweden, Name: "Sweden", CountryCode: "SE", CurrencyCode: "SEK", LanguageTag: "sv_SE", Domain: "amazon.se", TimeZone: "Europe/Stockholm", Region: EUWest, Endpoint: Europe},
+	{ID: SouthAfrica, Name: "South Africa", CountryCode: "ZA", CurrencyCode: "ZAR", LanguageTag: "en_ZA", Domain: "amazon.co.za", TimeZone: "Africa/Johannesburg", Region: EUWest, Endpoint: Europe},
+	{ID: Poland, Name: "Poland", CountryCode: "PL", CurrencyCode: "PLN", LanguageTag: "pl_PL", Domain: "amazon.pl", TimeZone: "Europe/Warsaw", Region: EUWest, Endpoint: Europe},
+	{ID: Egypt, Name: "Egypt", CountryCode: "EG", CurrencyCode: "EGP", LanguageTag: "ar_EG", Domain: "amazon.eg", TimeZone: "Africa/Cairo", Region: EUWest, Endpoint: Europe},
+	{ID: Turkey, Name: "Turkey", CountryCode: "TR", CurrencyCode: "TRY", LanguageTag: "tr_TR", Domain: "amazon.com.tr", TimeZone: "Europe/Istanbul", Region: EUWest, Endpoint: Europe},
+	{ID: SaudiArabia, Name: "Saudi Arabia", CountryCode: "SA", CurrencyCode: "SAR", LanguageTag: "ar_SA", Domain: "amazon.sa", TimeZone: "Asia/Riyadh", Region: EUWest, Endpoint: Europe},
+	{ID: UnitedArabEmirates, Name: "United Arab Emirates", CountryCode: "AE", CurrencyCode: "AED", LanguageTag: "ar_AE", Domain: "amazon.ae", TimeZone: "Asia/Dubai", Region: EUWest, Endpoint: Europe},
+	{ID: India, Name: "India", CountryCode: "IN", CurrencyCode: "INR", LanguageTag: "en_IN", Domain: "amazon.in", TimeZone: "Asia/Kolkata", Region: EUWest, Endpoint: Europe},
+	{ID: Singapore, Name: "Singapore", CountryCode: "SG", CurrencyCode: "SGD", LanguageTag: "en_SG", Domain: "amazon.sg", TimeZone: "Asia/Singapore", Region: USWest, Endpoint: FarEast},
+	{ID: Australia, Name: "Australia", CountryCode: "AU", CurrencyCode: "AUD", LanguageTag: "en_AU", Domain: "amazon.com.au", TimeZone: "Australia/Sydney", Region: USWest, Endpoint: FarEast},
+	{ID: Japan, Name: "Japan", CountryCode: "JP", CurrencyCode: "JPY", LanguageTag: "ja_JP", Domain: "amazon.co.jp", TimeZone: "Asia/Tokyo", Region: USWest, Endpoint: FarEast},
+}
+
+var (
+	marketplacesByID          = map[MarketplaceID]Marketplace{}
+	marketplacesByCountryCode = map[string]Marketplace{}
+)
+
+func init() {
+	for _, m := range marketplaces {
+		marketplacesByID[m.ID] = m
+		marketplacesByCountryCode[m.CountryCode] = m
+	}
+	// Amazon uses UK instead of the ISO code GB in its documentation
+	marketplacesByCountryCode["UK"] = marketplacesByID[UnitedKingdom]
+}
+
+// Marketplaces returns all known marketplaces grouped by region.
+func Marketplaces() []Marketplace {
+	result := make([]Marketplace, len(marketplaces))
+	copy(result, marketplaces)
+	return result
+}
+
+// MarketplacesOfEndpoint returns all marketplaces which are served by the endpoint.
+func MarketplacesOfEndpoint(endpoint Endpoint) []Marketplace {
+	var result []Marketplace
+	for _, m := range marketplaces {
+		if m.Endpoint == endpoint {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// MarketplaceByID returns the marketplace with the ID.
+func MarketplaceByID(id MarketplaceID) (Marketplace, bool) {
+	m, ok := marketplacesByID[id]
+	return m, ok
+}
+
+// MarketplaceByCountryCode returns the marketplace of the country, the code is case-insensitive.
+func MarketplaceByCountryCode(countryCode string) (Marketplace, bool) {
+	m, ok := marketplacesByCountryCode[strings.ToUpper(countryCode)]
+	return m, ok
+}
+
+// Marketplace returns the registry entry of the ID.
+func (id MarketplaceID) Marketplace() (Marketplace, bool) {
+	return MarketplaceByID(id)
+}
@@ -0,0 +1,66 @@
+package constants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketplaceByCountryCode(t *testing.T) {
+	tests := []struct {
+		countryCode  string
+		wantID       MarketplaceID
+		wantEndpoint Endpoint
+		wantOK       bool
+	}{
+		{countryCode: "DE", wantID: Germany, wantEndpoint: Europe, wantOK: true},
+		{countryCode: "de", wantID: Germany, wantEndpoint: Europe, wantOK: true},
+		{countryCode: "UK", wantID: UnitedKingdom, wantEndpoint: Europe, wantOK: true},
+		{countryCode: "GB", wantID: UnitedKingdom, wantEndpoint: Europe, wantOK: true},
+		{countryCode: "AU", wantID: Australia, wantEndpoint: FarEast, wantOK: true},
+		{countryCode: "ZA", wantID: SouthAfrica, wantEndpoint: Europe, wantOK: true},
+		{countryCode: "CH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.countryCode, func(t *testing.T) {
+			got, ok := MarketplaceByCountryCode(tt.countryCode)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantID, got.ID)
+			assert.Equal(t, tt.wantEndpoint, got.Endpoint)
+		})
+	}
+}
+
+func TestMarketplaces(t *testing.T) {
+	regions := map[Endpoint]Region{NorthAmerica: USEast, Europe: EUWest, FarEast: USWest}
+	for _, m := range Marketplaces() {
+		byID, ok := m.ID.Marketplace()
+		assert.True(t, ok, m.Name)
+		assert.Equal(t, m, byID)
+		assert.Equal(t, regions[m.Endpoint], m.Region, m.Name)
+		_, err := m.Location()
+		assert.NoError(t, err, m.Name)
+	}
+	assert.Len(t, MarketplacesOfEndpoint(FarEast), 3)
+}
+
+func TestEndpoint_Sandbox(t *testing.T) {
+	tests := []struct {
+		endpoint Endpoint
+		want     Endpoint
+		wantOK   bool
+	}{
+		{endpoint: NorthAmerica, want: SandboxNorthAmerica, wantOK: true},
+		{endpoint: Europe, want: SandboxEurope, wantOK: true},
+		{endpoint: SandboxFarEast, want: SandboxFarEast, wantOK: true},
+		{endpoint: "http://localhost:8080", want: "http://localhost:8080"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.endpoint), func(t *testing.T) {
+			got, ok := tt.endpoint.Sandbox()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, got.IsSandbox())
+		})
+	}
+}
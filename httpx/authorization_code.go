@@ -0,0 +1,39 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ExchangeAuthorizationCode exchanges the spapi_oauth_code of a selling partner authorization
+// for a refresh token via the LWA authorization_code grant. An empty tokenURL defaults to DefaultTokenURL.
+// The redirectURI must equal the one of the authorization request, if one was sent.
+func ExchangeAuthorizationCode(httpClient HTTPRequester, tokenURL string, clientID, clientSecret, code, redirectURI string) (*Credentials, error) {
+	if code == "" {
+		return nil, errors.New("authorization code is required")
+	}
+
+	params := map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          code,
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+	}
+	if redirectURI != "" {
+		params["redirect_uri"] = redirectURI
+	}
+	body, _ := json.Marshal(params)
+
+	resp, err := postTokenRequest(httpClient, valueOrDefault(tokenURL, DefaultTokenURL), body, discardLogger())
+	if err != nil {
+		return nil, err
+	}
+	if resp.RefreshToken == "" {
+		return nil, errors.New("authorization code response did not contain refresh token")
+	}
+	return &Credentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RefreshToken: resp.RefreshToken,
+	}, nil
+}
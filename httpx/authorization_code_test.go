@@ -0,0 +1,46 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingCloseBody is a response body whose Close fails.
+type failingCloseBody struct {
+	io.Reader
+}
+
+func (b failingCloseBody) Close() error {
+	return errors.New("close failed")
+}
+
+type failingCloseHTTPClient struct {
+	body string
+}
+
+func (c *failingCloseHTTPClient) Do(_ *http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func (c *failingCloseHTTPClient) Post(_ string, _ string, _ io.Reader) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: failingCloseBody{strings.NewReader(c.body)}}, nil
+}
+
+func TestExchangeAuthorizationCode(t *testing.T) {
+	body, err := json.Marshal(AccessTokenResponse{AccessToken: "accessToken", RefreshToken: "refreshToken", ExpiresIn: 3600})
+	require.NoError(t, err)
+
+	credentials, err := ExchangeAuthorizationCode(&failingCloseHTTPClient{body: string(body)}, "", "clientID", "clientSecret", "code", "")
+	require.NoError(t, err)
+	assert.Equal(t, &Credentials{ClientID: "clientID", ClientSecret: "clientSecret", RefreshToken: "refreshToken"}, credentials)
+
+	_, err = ExchangeAuthorizationCode(&failingCloseHTTPClient{}, "", "clientID", "clientSecret", "", "")
+	assert.EqualError(t, err, "authorization code is required")
+}
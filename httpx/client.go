@@ -1,36 +1,52 @@
 package httpx
 
 import (
-	"github.com/fond-of-vertigo/amazon-sp-api/constants"
 	"io"
 	"net/http"
+	"sync"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
 )
 
 type ClientConfig struct {
 	HTTPClient         HTTPRequester
 	TokenUpdaterConfig TokenUpdaterConfig
-	Endpoint           constants.Endpoint
+	// AccessTokenSource replaces the PeriodicTokenUpdater created from TokenUpdaterConfig if set.
+	AccessTokenSource AccessTokenSource
+	Endpoint          constants.Endpoint
 }
 
 func NewClient(config ClientConfig) (c *Client, err error) {
 	c = &Client{
-		httpClient: config.HTTPClient,
-		endpoint:   config.Endpoint,
+		httpClient:     config.HTTPClient,
+		endpoint:       config.Endpoint,
+		grantlessCache: NewGrantlessTokenCache(config.TokenUpdaterConfig),
 	}
 
-	c.tokenUpdater = newTokenUpdater(config.TokenUpdaterConfig)
-	if c.tokenUpdaterCancelFunc, err = c.tokenUpdater.RunInBackground(); err != nil {
-		return nil, err
+	c.tokenSource = config.AccessTokenSource
+	if c.tokenSource == nil {
+		c.tokenSource = newTokenUpdater(config.TokenUpdaterConfig)
+	}
+
+	c.tokenUpdaterCancelFunc = func() {}
+	if runner, ok := c.tokenSource.(backgroundRunner); ok {
+		if c.tokenUpdaterCancelFunc, err = runner.RunInBackground(); err != nil {
+			return nil, err
+		}
 	}
 
 	return c, nil
 }
 
 type Client struct {
-	tokenUpdater           tokenUpdater
+	tokenSource            AccessTokenSource
 	tokenUpdaterCancelFunc func()
 	httpClient             HTTPRequester
 	endpoint               constants.Endpoint
+	rdtProvider            apis.RestrictedDataTokenProvider
+	grantlessCache         *GrantlessTokenCache
+	closeOnce              sync.Once
 }
 
 type HTTPRequester interface {
@@ -38,27 +54,87 @@ type HTTPRequester interface {
 	Post(url string, bodyType string, body io.Reader) (*http.Response, error)
 }
 
-type tokenUpdater interface {
-	GetAccessToken() string
-	RunInBackground() (cancel func(), err error)
-}
-
+// Do sends the request with the current access token. If the SP-API rejects the token with
+// HTTP 401 or 403 and the AccessTokenSource supports it, the token is refreshed and the request
+// is sent once more. If the refresh fails or is skipped with ErrRefreshTooSoon, the rejected
+// response is returned.
 func (h *Client) Do(req *http.Request) (*http.Response, error) {
-	h.addAccessTokenToHeader(req)
+	accessToken := h.addAccessTokenToHeader(req)
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil || accessToken == "" ||
+		(resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) {
+		return resp, err
+	}
+
+	refresher, ok := h.tokenSource.(accessTokenRefresher)
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+	newAccessToken, refreshErr := refresher.RefreshAccessToken(accessToken)
+	if refreshErr != nil || newAccessToken == accessToken {
+		return resp, err
+	}
 
-	return h.httpClient.Do(req)
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retryReq.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	retryReq.Header.Set(constants.AccessTokenHeader, newAccessToken)
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return h.httpClient.Do(retryReq)
 }
 
 func (h *Client) GetEndpoint() constants.Endpoint {
 	return h.endpoint
 }
 
+// SetRestrictedDataTokenProvider enables the automatic acquisition of Restricted Data Tokens (RDTs)
+// for restricted operations which are called without an explicit RDT.
+func (h *Client) SetRestrictedDataTokenProvider(provider apis.RestrictedDataTokenProvider) {
+	h.rdtProvider = provider
+}
+
+// GetRestrictedDataToken returns an RDT of the configured provider or an empty token if
+// the automatic acquisition is disabled.
+func (h *Client) GetRestrictedDataToken(method string, path string, dataElements []string) (string, error) {
+	if h.rdtProvider == nil {
+		return "", nil
+	}
+	return h.rdtProvider.GetRestrictedDataToken(method, path, dataElements)
+}
+
+// GetGrantlessAccessToken returns an access token for grantless operations of the scope.
+func (h *Client) GetGrantlessAccessToken(scope constants.GrantlessScope) (string, error) {
+	return h.grantlessCache.GetGrantlessAccessToken(scope)
+}
+
+// TokenHealth returns the health state of the AccessTokenSource. The second return value is false
+// if the source does not report its health.
+func (h *Client) TokenHealth() (TokenHealth, bool) {
+	reporter, ok := h.tokenSource.(healthReporter)
+	if !ok {
+		return TokenHealth{}, false
+	}
+	return reporter.Health(), true
+}
+
+// Close stops the token updater. Calling Close more than once has no effect.
 func (h *Client) Close() {
-	h.tokenUpdaterCancelFunc()
+	h.closeOnce.Do(h.tokenUpdaterCancelFunc)
 }
 
-func (h *Client) addAccessTokenToHeader(req *http.Request) {
-	if req.Header.Get(constants.AccessTokenHeader) == "" {
-		req.Header.Add(constants.AccessTokenHeader, h.tokenUpdater.GetAccessToken())
+// addAccessTokenToHeader adds the access token unless the request already has a token (e.g. an RDT)
+// and returns the added token.
+func (h *Client) addAccessTokenToHeader(req *http.Request) string {
+	if req.Header.Get(constants.AccessTokenHeader) != "" {
+		return ""
 	}
+	accessToken := h.tokenSource.GetAccessToken()
+	req.Header.Add(constants.AccessTokenHeader, accessToken)
+	return accessToken
 }
@@ -2,7 +2,9 @@ package httpx
 
 import (
 	"bytes"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/fond-of-vertigo/amazon-sp-api/constants"
@@ -53,8 +55,8 @@ func Test_httpClient_addAccessToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := &Client{
-				httpClient:   nil,
-				tokenUpdater: tt.fields.TokenUpdater,
+				httpClient:  nil,
+				tokenSource: tt.fields.TokenUpdater,
 			}
 			h.addAccessTokenToHeader(tt.request)
 			if tt.request.Header.Get(constants.AccessTokenHeader) != tt.wantAccessToken {
@@ -63,3 +65,119 @@ func Test_httpClient_addAccessToken(t *testing.T) {
 		})
 	}
 }
+
+type refreshingTokenSource struct {
+	token        string
+	refreshCount int
+}
+
+func (s *refreshingTokenSource) GetAccessToken() string {
+	return s.token
+}
+
+func (s *refreshingTokenSource) RefreshAccessToken(staleToken string) (string, error) {
+	if s.token == staleToken {
+		s.refreshCount++
+		s.token = "NEW-ACCESS-TOKEN"
+	}
+	return s.token, nil
+}
+
+type recordingHTTPClient struct {
+	statusCodes []int
+	tokens      []string
+	bodies      []string
+}
+
+func (m *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	m.tokens = append(m.tokens, req.Header.Get(constants.AccessTokenHeader))
+	m.bodies = append(m.bodies, string(body))
+
+	resp := httptest.NewRecorder()
+	resp.WriteHeader(m.statusCodes[len(m.tokens)-1])
+	return resp.Result(), nil
+}
+
+func (m *recordingHTTPClient) Post(_ string, _ string, _ io.Reader) (*http.Response, error) {
+	return nil, nil
+}
+
+func Test_httpClient_DoRefreshesRejectedToken(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusCodes      []int
+		existingToken    string
+		wantStatusCode   int
+		wantTokens       []string
+		wantRefreshCount int
+	}{
+		{
+			name:           "Success",
+			statusCodes:    []int{http.StatusOK},
+			wantStatusCode: http.StatusOK,
+			wantTokens:     []string{"OLD-ACCESS-TOKEN"},
+		},
+		{
+			name:             "Retry with new token on 403",
+			statusCodes:      []int{http.StatusForbidden, http.StatusOK},
+			wantStatusCode:   http.StatusOK,
+			wantTokens:       []string{"OLD-ACCESS-TOKEN", "NEW-ACCESS-TOKEN"},
+			wantRefreshCount: 1,
+		},
+		{
+			name:             "Retry only once",
+			statusCodes:      []int{http.StatusUnauthorized, http.StatusUnauthorized},
+			wantStatusCode:   http.StatusUnauthorized,
+			wantTokens:       []string{"OLD-ACCESS-TOKEN", "NEW-ACCESS-TOKEN"},
+			wantRefreshCount: 1,
+		},
+		{
+			name:           "No retry with RestrictedDataToken",
+			statusCodes:    []int{http.StatusForbidden},
+			existingToken:  "EXISTING-RDT",
+			wantStatusCode: http.StatusForbidden,
+			wantTokens:     []string{"EXISTING-RDT"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &recordingHTTPClient{statusCodes: tt.statusCodes}
+			tokenSource := &refreshingTokenSource{token: "OLD-ACCESS-TOKEN"}
+			h := &Client{httpClient: httpClient, tokenSource: tokenSource}
+
+			req, _ := http.NewRequest(http.MethodPost, "example.com", bytes.NewBufferString("example"))
+			if tt.existingToken != "" {
+				req.Header.Add(constants.AccessTokenHeader, tt.existingToken)
+			}
+			resp, err := h.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("StatusCode %d != %d", resp.StatusCode, tt.wantStatusCode)
+			}
+			if len(httpClient.tokens) != len(tt.wantTokens) {
+				t.Fatalf("Tokens %v != %v", httpClient.tokens, tt.wantTokens)
+			}
+			for i := range tt.wantTokens {
+				if httpClient.tokens[i] != tt.wantTokens[i] || httpClient.bodies[i] != "example" {
+					t.Fatalf("Request %d sent token %s with body %s", i, httpClient.tokens[i], httpClient.bodies[i])
+				}
+			}
+			if tokenSource.refreshCount != tt.wantRefreshCount {
+				t.Fatalf("RefreshCount %d != %d", tokenSource.refreshCount, tt.wantRefreshCount)
+			}
+		})
+	}
+}
+
+func Test_httpClient_CloseTwice(t *testing.T) {
+	cancelCount := 0
+	h := &Client{tokenUpdaterCancelFunc: func() { cancelCount++ }}
+	h.Close()
+	h.Close()
+	if cancelCount != 1 {
+		t.Fatalf("CancelCount %d != 1", cancelCount)
+	}
+}
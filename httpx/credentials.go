@@ -0,0 +1,105 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	DefaultClientIDEnv     = "AMZN_CLIENT_ID"
+	DefaultClientSecretEnv = "AMZN_CLIENT_SECRET"
+	DefaultRefreshTokenEnv = "AMZN_REFRESH_TOKEN"
+)
+
+// Credentials are the Login with Amazon (LWA) credentials of an application and a seller.
+type Credentials struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// validate checks the application credentials. The refresh token is optional, as grantless
+// operations don't need one, it is checked before refresh token requests.
+func (c *Credentials) validate() error {
+	if c.ClientID == "" || c.ClientSecret == "" {
+		return errors.New("credentials must contain clientID and clientSecret")
+	}
+	return nil
+}
+
+// CredentialsProvider returns the LWA credentials. It is called before every token request,
+// so rotated secrets are picked up without a restart.
+type CredentialsProvider interface {
+	GetCredentials() (*Credentials, error)
+}
+
+// StaticCredentialsProvider always returns the same credentials.
+type StaticCredentialsProvider struct {
+	Credentials Credentials
+}
+
+func (p *StaticCredentialsProvider) GetCredentials() (*Credentials, error) {
+	credentials := p.Credentials
+	return &credentials, nil
+}
+
+// EnvCredentialsProvider reads the credentials from environment variables.
+// Empty names default to AMZN_CLIENT_ID, AMZN_CLIENT_SECRET and AMZN_REFRESH_TOKEN.
+// The refresh token may be unset for clients which only call grantless operations.
+type EnvCredentialsProvider struct {
+	ClientIDEnv     string
+	ClientSecretEnv string
+	RefreshTokenEnv string
+}
+
+func (p *EnvCredentialsProvider) GetCredentials() (*Credentials, error) {
+	credentials := &Credentials{
+		ClientID:     os.Getenv(valueOrDefault(p.ClientIDEnv, DefaultClientIDEnv)),
+		ClientSecret: os.Getenv(valueOrDefault(p.ClientSecretEnv, DefaultClientSecretEnv)),
+		RefreshToken: os.Getenv(valueOrDefault(p.RefreshTokenEnv, DefaultRefreshTokenEnv)),
+	}
+	if err := credentials.validate(); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// FileCredentialsProvider reads the credentials from a JSON file with the keys client_id,
+// client_secret and refresh_token, e.g. a file rendered by a Vault agent. refresh_token may be
+// omitted for clients which only call grantless operations.
+// The file is read on every call.
+type FileCredentialsProvider struct {
+	Path string
+}
+
+func (p *FileCredentialsProvider) GetCredentials() (*Credentials, error) {
+	content, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := &Credentials{}
+	if err := json.Unmarshal(content, credentials); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %w", p.Path, err)
+	}
+	if err := credentials.validate(); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// CredentialsProviderFunc adapts a function to the CredentialsProvider interface.
+type CredentialsProviderFunc func() (*Credentials, error)
+
+func (f CredentialsProviderFunc) GetCredentials() (*Credentials, error) {
+	return f()
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
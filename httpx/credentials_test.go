@@ -0,0 +1,96 @@
+package httpx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvCredentialsProvider_GetCredentials(t *testing.T) {
+	t.Setenv(DefaultClientIDEnv, "clientID")
+	t.Setenv(DefaultClientSecretEnv, "clientSecret")
+	t.Setenv(DefaultRefreshTokenEnv, "refreshToken")
+	t.Setenv("OTHER_REFRESH_TOKEN", "otherRefreshToken")
+
+	tests := []struct {
+		name     string
+		provider *EnvCredentialsProvider
+		want     *Credentials
+		wantErr  bool
+	}{
+		{
+			name:     "Default variables",
+			provider: &EnvCredentialsProvider{},
+			want:     &Credentials{ClientID: "clientID", ClientSecret: "clientSecret", RefreshToken: "refreshToken"},
+		},
+		{
+			name:     "Custom variable",
+			provider: &EnvCredentialsProvider{RefreshTokenEnv: "OTHER_REFRESH_TOKEN"},
+			want:     &Credentials{ClientID: "clientID", ClientSecret: "clientSecret", RefreshToken: "otherRefreshToken"},
+		},
+		{
+			name:     "Grantless without refresh token",
+			provider: &EnvCredentialsProvider{RefreshTokenEnv: "MISSING_REFRESH_TOKEN"},
+			want:     &Credentials{ClientID: "clientID", ClientSecret: "clientSecret"},
+		},
+		{
+			name:     "Missing variable",
+			provider: &EnvCredentialsProvider{ClientSecretEnv: "MISSING_CLIENT_SECRET"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.GetCredentials()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFileCredentialsProvider_GetCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	provider := &FileCredentialsProvider{Path: path}
+
+	_, err := provider.GetCredentials()
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"client_id":"clientID","client_secret":"secret1","refresh_token":"refreshToken"}`), 0o600))
+	got, err := provider.GetCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{ClientID: "clientID", ClientSecret: "secret1", RefreshToken: "refreshToken"}, got)
+
+	// rotated secrets are picked up on the next call
+	assert.NoError(t, os.WriteFile(path, []byte(`{"client_id":"clientID","client_secret":"secret2","refresh_token":"refreshToken"}`), 0o600))
+	got, err = provider.GetCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret2", got.ClientSecret)
+
+	// grantless clients don't need a refresh token
+	assert.NoError(t, os.WriteFile(path, []byte(`{"client_id":"clientID","client_secret":"secret2"}`), 0o600))
+	got, err = provider.GetCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{ClientID: "clientID", ClientSecret: "secret2"}, got)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"client_id":"clientID"`), 0o600))
+	_, err = provider.GetCredentials()
+	assert.Error(t, err)
+}
+
+func TestPeriodicTokenUpdater_RequiresRefreshToken(t *testing.T) {
+	httpClient := &sequenceHTTPClient{}
+	tu := newTokenUpdater(TokenUpdaterConfig{
+		CredentialsProvider: &StaticCredentialsProvider{Credentials: Credentials{ClientID: "clientID", ClientSecret: "clientSecret"}},
+		HTTPClient:          httpClient,
+	})
+
+	_, err := tu.fetchToken("")
+	assert.EqualError(t, err, "credentials must contain refreshToken")
+	assert.Equal(t, 0, httpClient.postCount)
+}
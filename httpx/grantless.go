@@ -0,0 +1,78 @@
+package httpx
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/logger"
+)
+
+// GrantlessTokenCache fetches access tokens of the client_credentials grant for grantless operations
+// and caches them per scope until shortly before they expire.
+type GrantlessTokenCache struct {
+	credentials CredentialsProvider
+	tokenURL    string
+	httpClient  HTTPRequester
+	log         logger.Logger
+	now         func() time.Time
+
+	// mu is held during token requests, so that concurrent callers of a scope wait for the same token
+	mu     sync.Mutex
+	tokens map[constants.GrantlessScope]grantlessToken
+}
+
+type grantlessToken struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewGrantlessTokenCache creates a GrantlessTokenCache which uses the client ID and client secret
+// of the config. The refresh token is not needed for grantless operations.
+func NewGrantlessTokenCache(config TokenUpdaterConfig) *GrantlessTokenCache {
+	return &GrantlessTokenCache{
+		credentials: config.credentialsProvider(),
+		tokenURL:    valueOrDefault(config.TokenURL, DefaultTokenURL),
+		httpClient:  config.HTTPClient,
+		log:         config.logger(),
+		now:         time.Now,
+		tokens:      map[constants.GrantlessScope]grantlessToken{},
+	}
+}
+
+// GetGrantlessAccessToken returns a cached access token for the scope or requests a new one.
+func (c *GrantlessTokenCache) GetGrantlessAccessToken(scope constants.GrantlessScope) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if token, ok := c.tokens[scope]; ok && c.now().Add(constants.ExpiryDelta).Before(token.expiresAt) {
+		return token.accessToken, nil
+	}
+
+	credentials, err := c.credentials.GetCredentials()
+	if err != nil {
+		return "", err
+	}
+	c.log.Debugf("Fetching grantless access-token for scope %s", scope)
+	resp, err := postTokenRequest(c.httpClient, c.tokenURL, makeGrantlessRequestBody(scope, credentials.ClientID, credentials.ClientSecret), c.log)
+	if err != nil {
+		return "", err
+	}
+
+	c.tokens[scope] = grantlessToken{
+		accessToken: resp.AccessToken,
+		expiresAt:   c.now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}
+	return resp.AccessToken, nil
+}
+
+func makeGrantlessRequestBody(scope constants.GrantlessScope, clientID, clientSecret string) []byte {
+	body, _ := json.Marshal(map[string]string{
+		"grant_type":    "client_credentials",
+		"scope":         string(scope),
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+	})
+	return body
+}
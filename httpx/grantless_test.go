@@ -0,0 +1,50 @@
+package httpx
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantlessTokenCache_GetGrantlessAccessToken(t *testing.T) {
+	respBody, err := json.Marshal(AccessTokenResponse{AccessToken: "grantlessToken", ExpiresIn: 3600})
+	assert.NoError(t, err)
+
+	httpClient := &mockHTTPClient{
+		TB:               t,
+		URL:              DefaultTokenURL,
+		BodyType:         "application/json",
+		Body:             makeGrantlessRequestBody(constants.ScopeNotifications, "clientID", "clientSecret"),
+		MockResponseBody: respBody,
+	}
+	cache := NewGrantlessTokenCache(TokenUpdaterConfig{
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		HTTPClient:   httpClient,
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	token, err := cache.GetGrantlessAccessToken(constants.ScopeNotifications)
+	assert.NoError(t, err)
+	assert.Equal(t, "grantlessToken", token)
+
+	// cached until shortly before the expiry
+	now = now.Add(58 * time.Minute)
+	_, err = cache.GetGrantlessAccessToken(constants.ScopeNotifications)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, httpClient.PostCallCount)
+
+	now = now.Add(time.Minute)
+	_, err = cache.GetGrantlessAccessToken(constants.ScopeNotifications)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpClient.PostCallCount)
+
+	var body map[string]string
+	assert.NoError(t, json.Unmarshal(httpClient.Body, &body))
+	assert.Equal(t, "client_credentials", body["grant_type"])
+	assert.Equal(t, "sellingpartnerapi::notifications", body["scope"])
+}
@@ -0,0 +1,38 @@
+package httpx
+
+// AccessTokenSource provides the LWA access token which is added to every request.
+// PeriodicTokenUpdater is the default implementation.
+type AccessTokenSource interface {
+	GetAccessToken() string
+}
+
+// backgroundRunner is implemented by AccessTokenSources which keep their token up-to-date in a goroutine.
+// The Client starts it on creation and stops it on Close.
+type backgroundRunner interface {
+	RunInBackground() (cancel func(), err error)
+}
+
+// accessTokenRefresher is implemented by AccessTokenSources which can replace a token rejected by the SP-API.
+type accessTokenRefresher interface {
+	RefreshAccessToken(staleToken string) (string, error)
+}
+
+// healthReporter is implemented by AccessTokenSources which report their TokenHealth.
+type healthReporter interface {
+	Health() TokenHealth
+}
+
+// StaticAccessTokenSource always returns the same access token, e.g. for tests or short-lived jobs
+// which receive their token from elsewhere.
+type StaticAccessTokenSource string
+
+func (s StaticAccessTokenSource) GetAccessToken() string {
+	return string(s)
+}
+
+// AccessTokenSourceFunc adapts a function to the AccessTokenSource interface.
+type AccessTokenSourceFunc func() string
+
+func (f AccessTokenSourceFunc) GetAccessToken() string {
+	return f()
+}
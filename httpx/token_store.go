@@ -0,0 +1,364 @@
+package httpx
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// tokenStorePollInterval is the interval in which a PeriodicTokenUpdater checks the TokenStore
+// for a new token while another process refreshes it.
+const tokenStorePollInterval = 250 * time.Millisecond
+
+// StoredToken is an access token shared via a TokenStore.
+type StoredToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// TokenStore shares access tokens between processes, so that only one of them requests a new
+// token from LWA and the others read the shared value.
+type TokenStore interface {
+	// Load returns the token stored for key or nil if there is none.
+	Load(key string) (*StoredToken, error)
+	// Store saves the token for key.
+	Store(key string, token StoredToken) error
+	// TryLock acquires the refresh lock for key without blocking. The lock expires after ttl
+	// in case the holder dies before calling unlock.
+	TryLock(key string, ttl time.Duration) (unlock func(), acquired bool, err error)
+}
+
+// tokenStoreKey derives the key of a token from the credentials without exposing secrets.
+func tokenStoreKey(credentials *Credentials) string {
+	hash := sha256.Sum256([]byte(credentials.ClientID + "\n" + credentials.RefreshToken))
+	return "sp-api-token-" + hex.EncodeToString(hash[:16])
+}
+
+// MemoryTokenStore is a TokenStore for clients within the same process.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]StoredToken
+	locks  map[string]time.Time
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: map[string]StoredToken{},
+		locks:  map[string]time.Time{},
+	}
+}
+
+func (s *MemoryTokenStore) Load(key string) (*StoredToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) Store(key string, token StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = token
+	return nil
+}
+
+func (s *MemoryTokenStore) TryLock(key string, ttl time.Duration) (unlock func(), acquired bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := s.locks[key]; ok && now.Before(expiresAt) {
+		return nil, false, nil
+	}
+	expiresAt := now.Add(ttl)
+	s.locks[key] = expiresAt
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.locks[key] == expiresAt {
+			delete(s.locks, key)
+		}
+	}, true, nil
+}
+
+// FileTokenStore is a TokenStore for processes on the same host or with a shared volume.
+// Tokens are stored as JSON files in Dir, the refresh lock is a lock file created exclusively
+// which contains a random owner token, so that a holder only removes its own lock.
+type FileTokenStore struct {
+	Dir string
+}
+
+func (s *FileTokenStore) Load(key string) (*StoredToken, error) {
+	content, err := os.ReadFile(s.path(key, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := &StoredToken{}
+	if err := json.Unmarshal(content, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *FileTokenStore) Store(key string, token StoredToken) error {
+	content, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that readers never see a partial token
+	tmp, err := os.CreateTemp(s.Dir, key+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key, ".json"))
+}
+
+func (s *FileTokenStore) TryLock(key string, ttl time.Duration) (unlock func(), acquired bool, err error) {
+	lockPath := s.path(key, ".lock")
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, false, err
+	}
+
+	acquired, err = createLockFile(lockPath, owner)
+	if err != nil {
+		return nil, false, err
+	}
+	if !acquired {
+		// the holder did not unlock in time
+		broken, err := takeLockFile(lockPath, func(taken string) bool {
+			info, err := os.Stat(taken)
+			return err == nil && time.Since(info.ModTime()) > ttl
+		})
+		if err != nil || !broken {
+			return nil, false, err
+		}
+		if acquired, err = createLockFile(lockPath, owner); err != nil || !acquired {
+			return nil, false, err
+		}
+	}
+
+	return func() {
+		_, _ = takeLockFile(lockPath, func(taken string) bool {
+			content, err := os.ReadFile(taken)
+			return err == nil && string(content) == owner
+		})
+	}, true, nil
+}
+
+// createLockFile creates the lock file with the owner token and reports false if it already exists.
+func createLockFile(lockPath, owner string) (bool, error) {
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if _, err := f.WriteString(owner); err != nil {
+		_ = f.Close()
+		_ = os.Remove(lockPath)
+		return false, err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(lockPath)
+		return false, err
+	}
+	return true, nil
+}
+
+// takeLockFile renames the lock file to a unique path and removes it if remove reports true for it.
+// Otherwise it is linked back, which fails instead of replacing a lock file created in the meantime.
+// As the rename is atomic, the verified file is always the removed one, even if other processes
+// unlock or break the same lock concurrently.
+func takeLockFile(lockPath string, remove func(taken string) bool) (bool, error) {
+	suffix, err := newLockOwner()
+	if err != nil {
+		return false, err
+	}
+	taken := lockPath + "." + suffix
+	if err := os.Rename(lockPath, taken); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if remove(taken) {
+		return true, os.Remove(taken)
+	}
+	err = os.Link(taken, lockPath)
+	_ = os.Remove(taken)
+	if errors.Is(err, os.ErrExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// newLockOwner returns a random token which identifies the holder of a lock file.
+func newLockOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *FileTokenStore) path(key, extension string) string {
+	return filepath.Join(s.Dir, key+extension)
+}
+
+// KeyValueBackend is the subset of a key-value database like Redis which is needed
+// by NewKeyValueTokenStore. Get returns found=false for missing keys.
+type KeyValueBackend interface {
+	Get(key string) (value string, found bool, err error)
+	Set(key string, value string, ttl time.Duration) error
+	// SetNX sets the key only if it does not exist yet and reports whether it was set.
+	SetNX(key string, value string, ttl time.Duration) (bool, error)
+	// DeleteIfEquals deletes the key only if its value equals value, which must be atomic,
+	// e.g. a Lua script with Redis.
+	DeleteIfEquals(key string, value string) error
+}
+
+// NewKeyValueTokenStore creates a TokenStore which keeps the tokens and locks in a KeyValueBackend,
+// e.g. a thin wrapper around a Redis client.
+func NewKeyValueTokenStore(backend KeyValueBackend) TokenStore {
+	return &keyValueTokenStore{backend: backend}
+}
+
+type keyValueTokenStore struct {
+	backend KeyValueBackend
+}
+
+func (s *keyValueTokenStore) Load(key string) (*StoredToken, error) {
+	value, found, err := s.backend.Get(key)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	token := &StoredToken{}
+	if err := json.Unmarshal([]byte(value), token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *keyValueTokenStore) Store(key string, token StoredToken) error {
+	value, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return s.backend.Set(key, string(value), time.Until(token.ExpiresAt))
+}
+
+func (s *keyValueTokenStore) TryLock(key string, ttl time.Duration) (unlock func(), acquired bool, err error) {
+	lockKey := key + ":lock"
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, false, err
+	}
+	acquired, err = s.backend.SetNX(lockKey, owner, ttl)
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+	return func() {
+		// the lock may have expired and been acquired by another process
+		_ = s.backend.DeleteIfEquals(lockKey, owner)
+	}, true, nil
+}
+
+// fetchSharedToken returns a valid token of the TokenStore or requests a new one if this process
+// gets the refresh lock. Other processes wait for the new token and fall back to their own
+// request if it does not appear within constants.TokenStoreLockTimeout.
+func (t *PeriodicTokenUpdater) fetchSharedToken(credentials *Credentials, staleToken string) (*AccessTokenResponse, error) {
+	key := tokenStoreKey(credentials)
+	deadline := t.now().Add(constants.TokenStoreLockTimeout)
+
+	for {
+		if token := t.loadStoredToken(key, staleToken); token != nil {
+			return token, nil
+		}
+
+		unlock, acquired, err := t.store.TryLock(key, constants.TokenStoreLockTimeout)
+		if err != nil {
+			t.log.Errorf("Failed to lock token store: %s", err.Error())
+			return t.doTokenRequest(credentials)
+		}
+		if acquired {
+			defer unlock()
+			// another process may have stored a token between loading and locking
+			if token := t.loadStoredToken(key, staleToken); token != nil {
+				return token, nil
+			}
+
+			token, err := t.doTokenRequest(credentials)
+			if err != nil {
+				return nil, err
+			}
+			stored := StoredToken{
+				AccessToken: token.AccessToken,
+				ExpiresAt:   t.now().Add(time.Duration(token.ExpiresIn) * time.Second),
+			}
+			if err := t.store.Store(key, stored); err != nil {
+				t.log.Errorf("Failed to store access token: %s", err.Error())
+			}
+			return token, nil
+		}
+
+		if !t.now().Before(deadline) {
+			t.log.Warnf("Token store was locked for %s, requesting access token directly.", constants.TokenStoreLockTimeout)
+			return t.doTokenRequest(credentials)
+		}
+		t.sleep(tokenStorePollInterval)
+	}
+}
+
+// loadStoredToken returns the stored token if it is valid for more than constants.ExpiryDelta
+// and was not rejected before.
+func (t *PeriodicTokenUpdater) loadStoredToken(key string, staleToken string) *AccessTokenResponse {
+	stored, err := t.store.Load(key)
+	if err != nil {
+		t.log.Errorf("Failed to load access token from token store: %s", err.Error())
+		return nil
+	}
+	if stored == nil || stored.AccessToken == "" || stored.AccessToken == staleToken {
+		return nil
+	}
+
+	expiresIn := stored.ExpiresAt.Sub(t.now())
+	if expiresIn <= constants.ExpiryDelta {
+		return nil
+	}
+	return &AccessTokenResponse{
+		AccessToken: stored.AccessToken,
+		ExpiresIn:   int(expiresIn.Seconds()),
+		TokenType:   "bearer",
+	}
+}
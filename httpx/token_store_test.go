@@ -0,0 +1,222 @@
+package httpx
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fond-of-vertigo/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockKeyValueBackend struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (b *mockKeyValueBackend) Get(key string) (string, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	value, ok := b.values[key]
+	return value, ok, nil
+}
+
+func (b *mockKeyValueBackend) Set(key string, value string, _ time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.values[key] = value
+	return nil
+}
+
+func (b *mockKeyValueBackend) SetNX(key string, value string, _ time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.values[key]; ok {
+		return false, nil
+	}
+	b.values[key] = value
+	return true, nil
+}
+
+func (b *mockKeyValueBackend) DeleteIfEquals(key string, value string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.values[key] == value {
+		delete(b.values, key)
+	}
+	return nil
+}
+
+// expire removes the key as if its ttl passed.
+func (b *mockKeyValueBackend) expire(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.values, key)
+}
+
+func TestTokenStores(t *testing.T) {
+	tests := []struct {
+		name  string
+		store TokenStore
+	}{
+		{name: "Memory", store: NewMemoryTokenStore()},
+		{name: "File", store: &FileTokenStore{Dir: t.TempDir()}},
+		{name: "KeyValue", store: NewKeyValueTokenStore(&mockKeyValueBackend{values: map[string]string{}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.store.Load("key")
+			require.NoError(t, err)
+			assert.Nil(t, token)
+
+			stored := StoredToken{AccessToken: "accessToken", ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second).UTC()}
+			require.NoError(t, tt.store.Store("key", stored))
+			token, err = tt.store.Load("key")
+			require.NoError(t, err)
+			assert.Equal(t, &stored, token)
+
+			unlock, acquired, err := tt.store.TryLock("key", time.Minute)
+			require.NoError(t, err)
+			assert.True(t, acquired)
+
+			_, acquired, err = tt.store.TryLock("key", time.Minute)
+			require.NoError(t, err)
+			assert.False(t, acquired)
+
+			unlock()
+			unlock, acquired, err = tt.store.TryLock("key", time.Minute)
+			require.NoError(t, err)
+			assert.True(t, acquired)
+			unlock()
+		})
+	}
+}
+
+func TestFileTokenStore_TryLockStale(t *testing.T) {
+	store := &FileTokenStore{Dir: t.TempDir()}
+	lockPath := store.path("key", ".lock")
+
+	unlockStale, acquired, err := store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	expired := time.Now().Add(-2 * time.Minute)
+	require.NoError(t, os.Chtimes(lockPath, expired, expired))
+
+	// the expired lock is broken
+	unlock, acquired, err := store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// the previous holder does not remove the lock of the new holder
+	unlockStale()
+	_, acquired, err = store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	unlock()
+	unlock, acquired, err = store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	unlock()
+
+	entries, err := os.ReadDir(store.Dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no lock files are left behind")
+}
+
+func TestKeyValueTokenStore_TryLockExpired(t *testing.T) {
+	backend := &mockKeyValueBackend{values: map[string]string{}}
+	store := NewKeyValueTokenStore(backend)
+
+	unlockExpired, acquired, err := store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	backend.expire("key:lock")
+
+	unlock, acquired, err := store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// the previous holder does not remove the lock of the new holder
+	unlockExpired()
+	_, acquired, err = store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	unlock()
+	unlock, acquired, err = store.TryLock("key", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	unlock()
+}
+
+func newSharedTokenUpdater(t *testing.T, store TokenStore, response AccessTokenResponse) *PeriodicTokenUpdater {
+	respBody, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	return newTokenUpdater(TokenUpdaterConfig{
+		RefreshToken: "refreshToken",
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		TokenStore:   store,
+		HTTPClient: &mockHTTPClient{
+			TB:               t,
+			URL:              DefaultTokenURL,
+			BodyType:         "application/json",
+			Body:             makeRequestBody("refreshToken", "clientID", "clientSecret"),
+			MockResponseBody: respBody,
+		},
+		Logger: logger.New(logger.LvlTrace),
+	})
+}
+
+func TestPeriodicTokenUpdater_fetchSharedToken(t *testing.T) {
+	store := NewMemoryTokenStore()
+	first := newSharedTokenUpdater(t, store, AccessTokenResponse{AccessToken: "accessToken", ExpiresIn: 3600})
+	second := newSharedTokenUpdater(t, store, AccessTokenResponse{AccessToken: "otherAccessToken", ExpiresIn: 3600})
+
+	token, err := first.fetchToken("")
+	require.NoError(t, err)
+	assert.Equal(t, "accessToken", token.AccessToken)
+
+	token, err = second.fetchToken("")
+	require.NoError(t, err)
+	assert.Equal(t, "accessToken", token.AccessToken)
+	assert.InDelta(t, 3600, token.ExpiresIn, 1)
+
+	assert.Equal(t, 1, first.httpClient.(*mockHTTPClient).PostCallCount)
+	assert.Equal(t, 0, second.httpClient.(*mockHTTPClient).PostCallCount)
+
+	// expired tokens are refreshed
+	second.now = func() time.Time { return time.Now().Add(time.Hour) }
+	token, err = second.fetchToken("")
+	require.NoError(t, err)
+	assert.Equal(t, "otherAccessToken", token.AccessToken)
+	assert.Equal(t, 1, second.httpClient.(*mockHTTPClient).PostCallCount)
+}
+
+func TestPeriodicTokenUpdater_fetchSharedTokenWaitsForLock(t *testing.T) {
+	store := NewMemoryTokenStore()
+	key := tokenStoreKey(&Credentials{ClientID: "clientID", RefreshToken: "refreshToken"})
+	unlock, acquired, err := store.TryLock(key, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	tu := newSharedTokenUpdater(t, store, AccessTokenResponse{AccessToken: "ownAccessToken", ExpiresIn: 3600})
+	sleepCount := 0
+	tu.sleep = func(time.Duration) {
+		// the lock holder stores its token while we wait
+		sleepCount++
+		require.NoError(t, store.Store(key, StoredToken{AccessToken: "sharedAccessToken", ExpiresAt: time.Now().Add(time.Hour)}))
+		unlock()
+	}
+
+	token, err := tu.fetchToken("")
+	require.NoError(t, err)
+	assert.Equal(t, "sharedAccessToken", token.AccessToken)
+	assert.Equal(t, 1, sleepCount)
+	assert.Equal(t, 0, tu.httpClient.(*mockHTTPClient).PostCallCount)
+}
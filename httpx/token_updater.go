@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -13,23 +14,108 @@ import (
 	"github.com/fond-of-vertigo/logger"
 )
 
-const tokenURL = "https://api.amazon.com/auth/o2/token"
+// DefaultTokenURL is the Login with Amazon (LWA) endpoint for access token requests.
+const DefaultTokenURL = "https://api.amazon.com/auth/o2/token"
 
 type TokenUpdaterConfig struct {
 	RefreshToken string
 	ClientID     string
 	ClientSecret string
-	HTTPClient   HTTPRequester
-	Logger       logger.Logger
+	// CredentialsProvider replaces RefreshToken, ClientID and ClientSecret if set.
+	// It is called before every token request.
+	CredentialsProvider CredentialsProvider
+	// TokenURL overrides DefaultTokenURL, e.g. for a proxy or a mock server.
+	TokenURL string
+	// TokenStore shares the access token with other processes if set.
+	TokenStore TokenStore
+	// InitialFetchAttempts is the number of attempts for the first token request with an exponential
+	// backoff in between. Defaults to constants.DefaultTokenUpdaterInitialAttempts.
+	InitialFetchAttempts int
+	HTTPClient           HTTPRequester
+	// Logger defaults to a logger which discards all messages.
+	Logger logger.Logger
+}
+
+// logger returns the Logger or a logger which discards all messages.
+func (c *TokenUpdaterConfig) logger() logger.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return discardLogger()
+}
+
+// discardLogger returns a logger which discards all messages.
+func discardLogger() logger.Logger {
+	return logger.NewWithWriter(logger.LvlError, io.Discard)
+}
+
+// credentialsProvider returns the CredentialsProvider or a StaticCredentialsProvider of the literal credentials.
+func (c *TokenUpdaterConfig) credentialsProvider() CredentialsProvider {
+	if c.CredentialsProvider != nil {
+		return c.CredentialsProvider
+	}
+	return &StaticCredentialsProvider{Credentials: Credentials{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		RefreshToken: c.RefreshToken,
+	}}
 }
 
 type PeriodicTokenUpdater struct {
-	accessToken  atomic.Pointer[string]
-	refreshToken string
-	clientID     string
-	clientSecret string
-	httpClient   HTTPRequester
-	log          logger.Logger
+	accessToken atomic.Pointer[string]
+	credentials CredentialsProvider
+	tokenURL    string
+	store       TokenStore
+	httpClient  HTTPRequester
+	log         logger.Logger
+	now         func() time.Time
+	sleep       func(d time.Duration)
+
+	initialFetchAttempts int
+	// refreshMu serializes token refreshes, see RefreshAccessToken
+	refreshMu sync.Mutex
+	healthMu  sync.Mutex
+	health    TokenHealth
+}
+
+// TokenHealth describes the state of a PeriodicTokenUpdater, e.g. for health checks.
+type TokenHealth struct {
+	// LastSuccess is the time of the last successful token fetch.
+	LastSuccess time.Time
+	// LastError is the error of the last token fetch or nil if it succeeded.
+	LastError error
+	// LastErrorAt is the time of the last failed token fetch.
+	LastErrorAt time.Time
+	// ExpiresAt is the expiry of the current access token.
+	ExpiresAt time.Time
+}
+
+// Healthy reports whether the current access token is valid at the given time.
+func (h TokenHealth) Healthy(now time.Time) bool {
+	return now.Before(h.ExpiresAt)
+}
+
+// ErrRefreshTooSoon is returned by RefreshAccessToken if the token is younger than
+// constants.MinOnDemandTokenRefreshInterval and therefore not refreshed.
+var ErrRefreshTooSoon = errors.New("access token was refreshed too recently")
+
+// TokenError is returned if LWA rejects a token request.
+type TokenError struct {
+	Code        string
+	Description string
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("token request failed with %s: %s", e.Code, e.Description)
+}
+
+// permanent reports whether the request cannot succeed without changing the credentials.
+func (e *TokenError) permanent() bool {
+	switch e.Code {
+	case "invalid_grant", "invalid_client", "unauthorized_client", "invalid_request":
+		return true
+	}
+	return false
 }
 
 type AccessTokenResponse struct {
@@ -42,12 +128,20 @@ type AccessTokenResponse struct {
 }
 
 func newTokenUpdater(config TokenUpdaterConfig) *PeriodicTokenUpdater {
+	initialFetchAttempts := config.InitialFetchAttempts
+	if initialFetchAttempts < 1 {
+		initialFetchAttempts = constants.DefaultTokenUpdaterInitialAttempts
+	}
+
 	return &PeriodicTokenUpdater{
-		refreshToken: config.RefreshToken,
-		clientID:     config.ClientID,
-		clientSecret: config.ClientSecret,
-		log:          config.Logger,
-		httpClient:   config.HTTPClient,
+		initialFetchAttempts: initialFetchAttempts,
+		credentials:          config.credentialsProvider(),
+		tokenURL:             valueOrDefault(config.TokenURL, DefaultTokenURL),
+		store:                config.TokenStore,
+		log:                  config.logger(),
+		httpClient:           config.HTTPClient,
+		now:                  time.Now,
+		sleep:                time.Sleep,
 	}
 }
 
@@ -60,6 +154,36 @@ func (t *PeriodicTokenUpdater) GetAccessToken() string {
 	return *token
 }
 
+// Health returns the current TokenHealth.
+func (t *PeriodicTokenUpdater) Health() TokenHealth {
+	t.healthMu.Lock()
+	defer t.healthMu.Unlock()
+	return t.health
+}
+
+// RefreshAccessToken fetches a new access token because staleToken was rejected by the SP-API.
+// Concurrent calls are coalesced: if the token was already replaced, the current token is returned
+// without a new request. Tokens younger than constants.MinOnDemandTokenRefreshInterval are not refreshed,
+// in that case staleToken is returned with ErrRefreshTooSoon.
+func (t *PeriodicTokenUpdater) RefreshAccessToken(staleToken string) (string, error) {
+	t.refreshMu.Lock()
+	defer t.refreshMu.Unlock()
+
+	if current := t.GetAccessToken(); current != staleToken {
+		return current, nil
+	}
+	if t.now().Sub(t.Health().LastSuccess) < constants.MinOnDemandTokenRefreshInterval {
+		return staleToken, ErrRefreshTooSoon
+	}
+
+	t.log.Infof("Access token was rejected, fetching a new one.")
+	token, err := t.fetchToken(staleToken)
+	if err != nil {
+		return staleToken, err
+	}
+	return token.AccessToken, nil
+}
+
 // RunInBackground starts a goroutine that fetches a new access token periodically
 // and stores it in the client. The goroutine is stopped when the returned cancel function is called.
 func (t *PeriodicTokenUpdater) RunInBackground() (cancel func(), err error) {
@@ -69,7 +193,7 @@ func (t *PeriodicTokenUpdater) RunInBackground() (cancel func(), err error) {
 	}
 
 	ticker := time.NewTicker(durationNextFetch)
-	done := make(chan bool)
+	done := make(chan struct{})
 
 	go func() {
 		for {
@@ -78,22 +202,26 @@ func (t *PeriodicTokenUpdater) RunInBackground() (cancel func(), err error) {
 				t.log.Infof("Stopped goroutine of token-updater.")
 				return
 			case <-ticker.C:
-				token, err := t.doTokenRequest()
+				t.refreshMu.Lock()
+				token, err := t.fetchToken("")
+				t.refreshMu.Unlock()
 				if err != nil {
 					t.log.Errorf("Failed to fetch new access-tokenAPI: %s", err.Error())
 					ticker.Reset(constants.DefaultTokenUpdaterBackoffTime)
 					continue
 				}
-				t.accessToken.Store(&token.AccessToken)
 				durationToWait := durationBetweenTokenRequests(token)
 				ticker.Reset(durationToWait)
 			}
 		}
 	}()
 
+	var once sync.Once
 	cancelFunc := func() {
-		ticker.Stop()
-		done <- true
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
 	}
 	return cancelFunc, nil
 
@@ -101,11 +229,22 @@ func (t *PeriodicTokenUpdater) RunInBackground() (cancel func(), err error) {
 
 func (t *PeriodicTokenUpdater) doInitialFetch() (time.Duration, error) {
 	t.log.Debugf("Fetching first access-tokenAPI")
-	token, err := t.doTokenRequest()
-	if err != nil {
-		return constants.DefaultTokenUpdaterBackoffTime, err
+	backoff := constants.DefaultTokenUpdaterInitialBackoffTime
+	var token *AccessTokenResponse
+	var err error
+	for attempt := 1; ; attempt++ {
+		if token, err = t.fetchToken(""); err == nil {
+			break
+		}
+
+		var tokenErr *TokenError
+		if attempt >= t.initialFetchAttempts || (errors.As(err, &tokenErr) && tokenErr.permanent()) {
+			return constants.DefaultTokenUpdaterBackoffTime, err
+		}
+		t.log.Warnf("Failed to fetch first access-token (attempt %d of %d), retrying in %s: %s", attempt, t.initialFetchAttempts, backoff, err.Error())
+		t.sleep(backoff)
+		backoff *= 2
 	}
-	t.accessToken.Store(&token.AccessToken)
 	durationNextFetch := durationBetweenTokenRequests(token)
 	return durationNextFetch, nil
 }
@@ -118,16 +257,52 @@ func durationBetweenTokenRequests(token *AccessTokenResponse) time.Duration {
 	return time.Duration(token.ExpiresIn-expiryDeltaSeconds) * time.Second
 }
 
-func (t *PeriodicTokenUpdater) doTokenRequest() (*AccessTokenResponse, error) {
-	body := makeRequestBody(t.refreshToken, t.clientID, t.clientSecret)
-	resp, err := t.httpClient.Post(tokenURL, "application/json", bytes.NewBuffer(body))
+// fetchToken fetches a new access token, which is read from the TokenStore if one is configured,
+// makes it the current token and updates the health state. A stored token equal to staleToken is ignored.
+func (t *PeriodicTokenUpdater) fetchToken(staleToken string) (token *AccessTokenResponse, err error) {
+	defer func() {
+		t.healthMu.Lock()
+		defer t.healthMu.Unlock()
+		now := t.now()
+		t.health.LastError = err
+		if err != nil {
+			t.health.LastErrorAt = now
+			return
+		}
+		t.health.LastSuccess = now
+		t.health.ExpiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+		t.accessToken.Store(&token.AccessToken)
+	}()
+
+	credentials, err := t.credentials.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	if credentials.RefreshToken == "" {
+		return nil, errors.New("credentials must contain refreshToken")
+	}
+
+	if t.store != nil {
+		return t.fetchSharedToken(credentials, staleToken)
+	}
+	return t.doTokenRequest(credentials)
+}
+
+func (t *PeriodicTokenUpdater) doTokenRequest(credentials *Credentials) (*AccessTokenResponse, error) {
+	body := makeRequestBody(credentials.RefreshToken, credentials.ClientID, credentials.ClientSecret)
+	return postTokenRequest(t.httpClient, t.tokenURL, body, t.log)
+}
+
+// postTokenRequest sends a token request to LWA and returns the access token of the response.
+func postTokenRequest(httpClient HTTPRequester, tokenURL string, body []byte, log logger.Logger) (*AccessTokenResponse, error) {
+	resp, err := httpClient.Post(tokenURL, "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
 
 	defer func(Body io.ReadCloser) {
 		if err := Body.Close(); err != nil {
-			t.log.Errorf(err.Error())
+			log.Errorf(err.Error())
 		}
 	}(resp.Body)
 
@@ -136,18 +311,21 @@ func (t *PeriodicTokenUpdater) doTokenRequest() (*AccessTokenResponse, error) {
 		return nil, err
 	}
 
-	tkn, err := t.parseAccessTokenResponse(respBody)
+	tkn, err := parseAccessTokenResponse(respBody)
 	if err != nil {
 		return nil, err
 	}
 
+	if tkn.Error != "" {
+		return nil, &TokenError{Code: tkn.Error, Description: tkn.ErrorDescription}
+	}
 	if tkn.AccessToken == "" {
 		return nil, errors.New("refreshToken response did not contain access token")
 	}
 	return tkn, nil
 }
 
-func (t *PeriodicTokenUpdater) parseAccessTokenResponse(body []byte) (*AccessTokenResponse, error) {
+func parseAccessTokenResponse(body []byte) (*AccessTokenResponse, error) {
 	parsedResp := &AccessTokenResponse{}
 	if err := json.Unmarshal(body, parsedResp); err != nil {
 		return nil, fmt.Errorf("refreshToken response parse failed. Body: %s", string(body))
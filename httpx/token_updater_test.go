@@ -73,7 +73,7 @@ func TestPeriodicTokenUpdater_RunInBackground(t *testing.T) {
 				RefreshToken:      "refreshToken",
 				ClientID:          "clientID",
 				ClientSecret:      "clientSecret",
-				ExpectedPostCount: 1,
+				ExpectedPostCount: 3,
 				MockTokenResponse: AccessTokenResponse{},
 			},
 		},
@@ -90,13 +90,14 @@ func TestPeriodicTokenUpdater_RunInBackground(t *testing.T) {
 				ClientSecret: tt.args.ClientSecret,
 				HTTPClient: &mockHTTPClient{
 					TB:               t,
-					URL:              tokenURL,
+					URL:              DefaultTokenURL,
 					BodyType:         "application/json",
 					Body:             makeRequestBody(tt.args.RefreshToken, tt.args.ClientID, tt.args.ClientSecret),
 					MockResponseBody: respBody,
 				},
 				Logger: logger.New(logger.LvlTrace),
 			})
+			tu.sleep = func(time.Duration) {}
 
 			//  when
 			cancel, err := tu.RunInBackground()
@@ -121,3 +122,125 @@ func TestPeriodicTokenUpdater_RunInBackground(t *testing.T) {
 		})
 	}
 }
+
+type sequenceHTTPClient struct {
+	responses []AccessTokenResponse
+	postCount int
+}
+
+func (m *sequenceHTTPClient) Do(_ *http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func (m *sequenceHTTPClient) Post(_ string, _ string, _ io.Reader) (*http.Response, error) {
+	body, _ := json.Marshal(m.responses[m.postCount])
+	m.postCount++
+	resp := httptest.NewRecorder()
+	_, _ = resp.Write(body)
+	return resp.Result(), nil
+}
+
+func newSequenceTokenUpdater(responses ...AccessTokenResponse) (*PeriodicTokenUpdater, *sequenceHTTPClient, *time.Time) {
+	httpClient := &sequenceHTTPClient{responses: responses}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tu := newTokenUpdater(TokenUpdaterConfig{
+		RefreshToken: "refreshToken",
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		HTTPClient:   httpClient,
+		Logger:       logger.New(logger.LvlTrace),
+	})
+	tu.now = func() time.Time { return now }
+	tu.sleep = func(time.Duration) {}
+	return tu, httpClient, &now
+}
+
+func TestPeriodicTokenUpdater_doInitialFetch(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []AccessTokenResponse
+		wantErr       bool
+		wantPostCount int
+	}{
+		{
+			name:          "Retry after temporary error",
+			responses:     []AccessTokenResponse{{Error: "server_error"}, {AccessToken: "accessToken", ExpiresIn: 3600}},
+			wantPostCount: 2,
+		},
+		{
+			name:          "No retry after invalid grant",
+			responses:     []AccessTokenResponse{{Error: "invalid_grant", ErrorDescription: "The request has an invalid grant parameter"}},
+			wantErr:       true,
+			wantPostCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu, httpClient, now := newSequenceTokenUpdater(tt.responses...)
+
+			_, err := tu.doInitialFetch()
+			health := tu.Health()
+			assert.Equal(t, tt.wantPostCount, httpClient.postCount)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, err, health.LastError)
+				assert.False(t, health.Healthy(*now))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "accessToken", tu.GetAccessToken())
+			assert.Equal(t, TokenHealth{LastSuccess: *now, LastErrorAt: *now, ExpiresAt: now.Add(time.Hour)}, health)
+			assert.True(t, health.Healthy(*now))
+		})
+	}
+}
+
+func TestPeriodicTokenUpdater_RefreshAccessToken(t *testing.T) {
+	tu, httpClient, now := newSequenceTokenUpdater(
+		AccessTokenResponse{AccessToken: "first", ExpiresIn: 3600},
+		AccessTokenResponse{AccessToken: "second", ExpiresIn: 3600},
+	)
+	_, err := tu.fetchToken("")
+	assert.NoError(t, err)
+
+	// a token which was just fetched is not refreshed again
+	token, err := tu.RefreshAccessToken("first")
+	assert.ErrorIs(t, err, ErrRefreshTooSoon)
+	assert.Equal(t, "first", token)
+	assert.Equal(t, 1, httpClient.postCount)
+
+	*now = now.Add(time.Minute)
+	token, err = tu.RefreshAccessToken("first")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+	assert.Equal(t, 2, httpClient.postCount)
+
+	// concurrent callers with the same stale token get the refreshed token without a new request
+	token, err = tu.RefreshAccessToken("first")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+	assert.Equal(t, 2, httpClient.postCount)
+}
+
+func TestPeriodicTokenUpdater_CancelTwice(t *testing.T) {
+	tu, _, _ := newSequenceTokenUpdater(AccessTokenResponse{AccessToken: "accessToken", ExpiresIn: 3600})
+
+	cancel, err := tu.RunInBackground()
+	assert.NoError(t, err)
+	cancel()
+	cancel()
+}
+
+func TestPeriodicTokenUpdater_WithoutLogger(t *testing.T) {
+	tu := newTokenUpdater(TokenUpdaterConfig{
+		RefreshToken: "refreshToken",
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		HTTPClient:   &sequenceHTTPClient{responses: []AccessTokenResponse{{AccessToken: "accessToken", ExpiresIn: 3600}}},
+	})
+
+	cancel, err := tu.RunInBackground()
+	assert.NoError(t, err)
+	cancel()
+	assert.Equal(t, "accessToken", tu.GetAccessToken())
+}
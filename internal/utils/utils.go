@@ -81,3 +81,14 @@ func UnmarshalJSONEnum[T ~string](src []byte, allowedValues *Set[T]) (*T, error)
 
 	return nil, fmt.Errorf("%+v is not a valid enum of type %T", value, enumTypeValue)
 }
+
+// UnmarshalJSONEnumLenient unmarshals an enum like UnmarshalJSONEnum, but keeps values which are
+// not allowed instead of failing. known reports whether the value is one of the allowedValues.
+func UnmarshalJSONEnumLenient[T ~string](src []byte, allowedValues *Set[T]) (value T, known bool, err error) {
+	var s string
+	if err = json.Unmarshal(src, &s); err != nil {
+		return value, false, err
+	}
+	value = T(s)
+	return value, allowedValues.Has(value), nil
+}
@@ -237,3 +237,28 @@ func TestUnmarshalJSONEnum(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalJSONEnumLenient(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		want      Enum
+		wantKnown bool
+		wantErr   bool
+	}{
+		{name: "Known value", src: `"A"`, want: EnumA, wantKnown: true},
+		{name: "Unknown value is kept", src: `"D"`, want: "D", wantKnown: false},
+		{name: "No string", src: `1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known, err := UnmarshalJSONEnumLenient[Enum]([]byte(tt.src), AllowedEnumValues)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSONEnumLenient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || known != tt.wantKnown {
+				t.Errorf("UnmarshalJSONEnumLenient() = %v, %v, want %v, %v", got, known, tt.want, tt.wantKnown)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package sp_api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+)
+
+type MultiRegionConfig struct {
+	// Config is the template for the client of every region. Its Endpoint, Marketplace and
+	// RefreshToken are replaced per region. Its CredentialsProvider must not be set, as it would
+	// replace the refresh token of every region, use NewCredentialsProvider instead.
+	Config Config
+	// RefreshTokens contains the refresh token of every region the seller authorized the application in,
+	// as each region is authorized separately. A client is created for every entry.
+	RefreshTokens map[constants.Endpoint]string
+	// NewCredentialsProvider optionally returns the CredentialsProvider of a region for its refresh token,
+	// e.g. ClientSecretRotator.CredentialsProvider of the applications API.
+	NewCredentialsProvider func(refreshToken string) httpx.CredentialsProvider
+}
+
+// MultiRegionClient holds one client per region and routes calls to the region which serves
+// the marketplaces of a request.
+type MultiRegionClient struct {
+	clients map[constants.Endpoint]*Client
+}
+
+func NewMultiRegionClient(config MultiRegionConfig) (*MultiRegionClient, error) {
+	return newMultiRegionClient(config, NewClient)
+}
+
+func newMultiRegionClient(config MultiRegionConfig, newClient func(config Config) (*Client, error)) (*MultiRegionClient, error) {
+	if len(config.RefreshTokens) == 0 {
+		return nil, errors.New("refreshTokens of at least one region are required")
+	}
+	if config.Config.CredentialsProvider != nil {
+		return nil, errors.New("the credentialsProvider of the template would be used for all regions, use newCredentialsProvider instead")
+	}
+
+	c := &MultiRegionClient{clients: map[constants.Endpoint]*Client{}}
+	for endpoint, refreshToken := range config.RefreshTokens {
+		if len(constants.MarketplacesOfEndpoint(endpoint)) == 0 {
+			c.Close()
+			return nil, fmt.Errorf("unknown endpoint %s", endpoint)
+		}
+
+		regionConfig := config.Config
+		regionConfig.Endpoint = endpoint
+		regionConfig.Marketplace = ""
+		regionConfig.RefreshToken = refreshToken
+		if config.NewCredentialsProvider != nil {
+			regionConfig.CredentialsProvider = config.NewCredentialsProvider(refreshToken)
+		}
+		client, err := newClient(regionConfig)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to create client for endpoint %s: %w", endpoint, err)
+		}
+		c.clients[endpoint] = client
+	}
+	return c, nil
+}
+
+// Endpoints returns the endpoints of all regions the client holds a client for.
+func (c *MultiRegionClient) Endpoints() []constants.Endpoint {
+	endpoints := make([]constants.Endpoint, 0, len(c.clients))
+	for endpoint := range c.clients {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i] < endpoints[j] })
+	return endpoints
+}
+
+// Region returns the client of the endpoint.
+func (c *MultiRegionClient) Region(endpoint constants.Endpoint) (*Client, error) {
+	client, ok := c.clients[endpoint]
+	if !ok {
+		return nil, fmt.Errorf("no client configured for endpoint %s", endpoint)
+	}
+	return client, nil
+}
+
+// ForMarketplaces returns the client of the region which serves all marketplaces.
+// It fails if the marketplaces belong to different regions, as a single call cannot span them.
+func (c *MultiRegionClient) ForMarketplaces(marketplaceIDs ...constants.MarketplaceID) (*Client, error) {
+	endpoint, err := endpointOfMarketplaces(marketplaceIDs)
+	if err != nil {
+		return nil, err
+	}
+	return c.Region(endpoint)
+}
+
+// For returns the client of the region which serves the marketplaces of the request, e.g.
+//
+//	client, err := c.For(specification)
+//	...
+//	client.ReportsAPI.CreateReport(specification)
+func (c *MultiRegionClient) For(request apis.MarketplaceScoped) (*Client, error) {
+	return c.ForMarketplaces(request.GetMarketplaceIDs()...)
+}
+
+// Close closes the clients of all regions.
+func (c *MultiRegionClient) Close() {
+	for _, client := range c.clients {
+		client.Close()
+	}
+}
+
+// endpointOfMarketplaces returns the endpoint which serves all marketplaces.
+func endpointOfMarketplaces(marketplaceIDs []constants.MarketplaceID) (constants.Endpoint, error) {
+	if len(marketplaceIDs) == 0 {
+		return "", errors.New("no marketplace IDs to determine the endpoint")
+	}
+
+	marketplacesByEndpoint := map[constants.Endpoint][]string{}
+	var endpoints []constants.Endpoint
+	for _, id := range marketplaceIDs {
+		marketplace, ok := constants.MarketplaceByID(id)
+		if !ok {
+			return "", fmt.Errorf("unknown marketplace %s", id)
+		}
+		if _, ok := marketplacesByEndpoint[marketplace.Endpoint]; !ok {
+			endpoints = append(endpoints, marketplace.Endpoint)
+		}
+		marketplacesByEndpoint[marketplace.Endpoint] = append(marketplacesByEndpoint[marketplace.Endpoint], marketplace.Name)
+	}
+
+	if len(endpoints) > 1 {
+		var regions []string
+		for _, endpoint := range endpoints {
+			regions = append(regions, fmt.Sprintf("%s (%s)", endpoint, strings.Join(marketplacesByEndpoint[endpoint], ", ")))
+		}
+		return "", fmt.Errorf("marketplaces span multiple regions, split the request per region: %s", strings.Join(regions, "; "))
+	}
+	return endpoints[0], nil
+}
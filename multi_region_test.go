@@ -0,0 +1,131 @@
+package sp_api
+
+import (
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_endpointOfMarketplaces(t *testing.T) {
+	tests := []struct {
+		name           string
+		marketplaceIDs []constants.MarketplaceID
+		want           constants.Endpoint
+		wantErr        bool
+	}{
+		{
+			name:           "Single region",
+			marketplaceIDs: []constants.MarketplaceID{constants.Germany, constants.France, constants.UnitedKingdom},
+			want:           constants.Europe,
+		},
+		{
+			name:           "Far east",
+			marketplaceIDs: []constants.MarketplaceID{constants.Japan},
+			want:           constants.FarEast,
+		},
+		{
+			name:           "Multiple regions",
+			marketplaceIDs: []constants.MarketplaceID{constants.Germany, constants.UnitedStatesOfAmerica},
+			wantErr:        true,
+		},
+		{
+			name:           "Unknown marketplace",
+			marketplaceIDs: []constants.MarketplaceID{"UNKNOWN"},
+			wantErr:        true,
+		},
+		{
+			name:    "No marketplaces",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := endpointOfMarketplaces(tt.marketplaceIDs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMultiRegionClient_For(t *testing.T) {
+	configs := map[constants.Endpoint]Config{}
+	c, err := newMultiRegionClient(MultiRegionConfig{
+		Config: Config{
+			AccessTokenSource: httpx.StaticAccessTokenSource("token"),
+			Marketplace:       constants.Germany,
+		},
+		RefreshTokens: map[constants.Endpoint]string{
+			constants.Europe:       "refresh-eu",
+			constants.NorthAmerica: "refresh-na",
+		},
+	}, func(config Config) (*Client, error) {
+		configs[config.Endpoint] = config
+		return NewClient(config)
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, []constants.Endpoint{constants.Europe, constants.NorthAmerica}, c.Endpoints())
+	assert.Equal(t, "refresh-na", configs[constants.NorthAmerica].RefreshToken)
+	assert.Empty(t, configs[constants.NorthAmerica].Marketplace)
+
+	eu, err := c.For(&reports.CreateReportSpecification{MarketplaceIDs: []constants.MarketplaceID{constants.Germany, constants.Italy}})
+	require.NoError(t, err)
+	assert.Same(t, c.clients[constants.Europe], eu)
+
+	na, err := c.ForMarketplaces(constants.Canada)
+	require.NoError(t, err)
+	assert.Same(t, c.clients[constants.NorthAmerica], na)
+
+	_, err = c.ForMarketplaces(constants.Japan)
+	assert.Error(t, err, "far east is not configured")
+
+	_, err = c.For(&reports.CreateReportSpecification{MarketplaceIDs: []constants.MarketplaceID{constants.Germany, constants.Canada}})
+	assert.Error(t, err)
+}
+
+func TestNewMultiRegionClient_CredentialsProvider(t *testing.T) {
+	refreshTokens := map[constants.Endpoint]string{
+		constants.Europe:       "refresh-eu",
+		constants.NorthAmerica: "refresh-na",
+	}
+	newCredentialsProvider := func(refreshToken string) httpx.CredentialsProvider {
+		return &httpx.StaticCredentialsProvider{Credentials: httpx.Credentials{
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+			RefreshToken: refreshToken,
+		}}
+	}
+
+	_, err := newMultiRegionClient(MultiRegionConfig{
+		Config:        Config{CredentialsProvider: newCredentialsProvider("refresh-eu")},
+		RefreshTokens: refreshTokens,
+	}, NewClient)
+	assert.ErrorContains(t, err, "credentialsProvider")
+
+	configs := map[constants.Endpoint]Config{}
+	c, err := newMultiRegionClient(MultiRegionConfig{
+		Config:                 Config{AccessTokenSource: httpx.StaticAccessTokenSource("token")},
+		RefreshTokens:          refreshTokens,
+		NewCredentialsProvider: newCredentialsProvider,
+	}, func(config Config) (*Client, error) {
+		configs[config.Endpoint] = config
+		return NewClient(config)
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	for endpoint, refreshToken := range refreshTokens {
+		credentials, err := configs[endpoint].CredentialsProvider.GetCredentials()
+		require.NoError(t, err)
+		assert.Equal(t, refreshToken, credentials.RefreshToken, endpoint)
+	}
+}
@@ -0,0 +1,52 @@
+package oauth
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Query parameters of the redirect from the consent page.
+const (
+	ParamState            = "state"
+	ParamOAuthCode        = "spapi_oauth_code"
+	ParamSellingPartnerID = "selling_partner_id"
+)
+
+// SuccessHandler is called with the completed authorization, e.g. to store the refresh token
+// and to render a confirmation page.
+type SuccessHandler func(w http.ResponseWriter, r *http.Request, authorization *Authorization)
+
+// ErrorHandler is called if the authorization cannot be completed.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// RedirectHandler returns the http.Handler for the redirect URI. It validates the state and exchanges
+// the authorization code. If onError is nil, errors are answered with HTTP 400 or 502.
+func (f *Flow) RedirectHandler(onSuccess SuccessHandler, onError ErrorHandler) http.Handler {
+	if onError == nil {
+		onError = defaultErrorHandler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		q := r.URL.Query()
+		authorization, err := f.Complete(q.Get(ParamState), q.Get(ParamOAuthCode), q.Get(ParamSellingPartnerID))
+		if err != nil {
+			onError(w, r, err)
+			return
+		}
+		onSuccess(w, r, authorization)
+	})
+}
+
+func defaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	if errors.Is(err, ErrInvalidState) || errors.Is(err, ErrMissingCode) {
+		http.Error(w, "authorization failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, "authorization failed", http.StatusBadGateway)
+}
@@ -0,0 +1,151 @@
+// Package oauth implements the website authorization workflow of the Selling Partner API,
+// which lets selling partners authorize an application and returns their refresh token.
+package oauth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+)
+
+// Consent page base URLs of Seller Central and Vendor Central. Selling partners must be sent to the
+// URL of the marketplace they sell in, see the Seller Central URL table of the SP-API documentation.
+const (
+	SellerCentralNorthAmerica = "https://sellercentral.amazon.com"
+	SellerCentralEurope       = "https://sellercentral-europe.amazon.com"
+	SellerCentralUK           = "https://sellercentral.amazon.co.uk"
+	SellerCentralGermany      = "https://sellercentral.amazon.de"
+	SellerCentralJapan        = "https://sellercentral.amazon.co.jp"
+	SellerCentralAustralia    = "https://sellercentral.amazon.com.au"
+	VendorCentralNorthAmerica = "https://vendorcentral.amazon.com"
+	VendorCentralEurope       = "https://vendorcentral.amazon.co.uk"
+	VendorCentralGermany      = "https://vendorcentral.amazon.de"
+	VendorCentralJapan        = "https://vendorcentral.amazon.co.jp"
+)
+
+const (
+	consentPath = "/apps/authorize/consent"
+	// DefaultStateTTL is the default duration in which a selling partner must complete the authorization.
+	DefaultStateTTL = 30 * time.Minute
+)
+
+var (
+	ErrInvalidState = errors.New("invalid or expired state")
+	ErrMissingCode  = errors.New("missing authorization code")
+)
+
+type Config struct {
+	// ApplicationID is the ID of the application in the Developer Console, e.g. amzn1.sp.solution.xxx.
+	ApplicationID string
+	ClientID      string
+	ClientSecret  string
+	// CentralURL is the base URL of the consent page, e.g. SellerCentralEurope.
+	CentralURL string
+	// RedirectURI is sent with the authorization request if set, it must be registered for the application.
+	RedirectURI string
+	// Draft adds version=beta to the consent URL, which is required to authorize applications in draft state.
+	Draft bool
+	// StateStore keeps the state of pending authorizations. Defaults to a MemoryStateStore.
+	StateStore StateStore
+	// StateTTL defaults to DefaultStateTTL.
+	StateTTL time.Duration
+	// TokenURL overrides the LWA endpoint for the code exchange.
+	TokenURL   string
+	HTTPClient httpx.HTTPRequester
+}
+
+// Authorization is the result of a completed authorization.
+type Authorization struct {
+	SellingPartnerID string
+	// Credentials can be passed to sp_api.Config, either as ClientID, ClientSecret and RefreshToken
+	// or as httpx.StaticCredentialsProvider.
+	Credentials httpx.Credentials
+}
+
+// Flow creates consent URLs and completes authorizations.
+type Flow struct {
+	config Config
+}
+
+func NewFlow(config Config) (*Flow, error) {
+	if config.ApplicationID == "" || config.ClientID == "" || config.ClientSecret == "" {
+		return nil, errors.New("applicationID, clientID and clientSecret are required")
+	}
+	if config.CentralURL == "" {
+		return nil, errors.New("centralURL is required")
+	}
+	if config.StateStore == nil {
+		config.StateStore = NewMemoryStateStore()
+	}
+	if config.StateTTL <= 0 {
+		config.StateTTL = DefaultStateTTL
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	return &Flow{config: config}, nil
+}
+
+// AuthorizationURL returns the consent URL the selling partner must be sent to.
+// It contains a new state which is validated when the selling partner is redirected back.
+func (f *Flow) AuthorizationURL() (string, error) {
+	state, err := newState()
+	if err != nil {
+		return "", err
+	}
+	if err := f.config.StateStore.Save(state, time.Now().Add(f.config.StateTTL)); err != nil {
+		return "", err
+	}
+	return f.consentURL(state), nil
+}
+
+func (f *Flow) consentURL(state string) string {
+	q := url.Values{}
+	q.Set("application_id", f.config.ApplicationID)
+	q.Set("state", state)
+	if f.config.Draft {
+		q.Set("version", "beta")
+	}
+	if f.config.RedirectURI != "" {
+		q.Set("redirect_uri", f.config.RedirectURI)
+	}
+	return f.config.CentralURL + consentPath + "?" + q.Encode()
+}
+
+// Complete validates the state of the redirect and exchanges the authorization code for a refresh token.
+// Each state can be used only once.
+func (f *Flow) Complete(state, code, sellingPartnerID string) (*Authorization, error) {
+	if code == "" {
+		return nil, ErrMissingCode
+	}
+	valid, err := f.config.StateStore.Consume(state)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrInvalidState
+	}
+
+	credentials, err := httpx.ExchangeAuthorizationCode(f.config.HTTPClient, f.config.TokenURL,
+		f.config.ClientID, f.config.ClientSecret, code, f.config.RedirectURI)
+	if err != nil {
+		return nil, err
+	}
+	return &Authorization{
+		SellingPartnerID: sellingPartnerID,
+		Credentials:      *credentials,
+	}, nil
+}
+
+func newState() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
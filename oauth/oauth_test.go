@@ -0,0 +1,120 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFlow(t *testing.T) *Flow {
+	lwa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		assert.Equal(t, "authorization_code", params["grant_type"])
+		assert.Equal(t, "https://example.com/callback", params["redirect_uri"])
+		if params["code"] != "valid-code" {
+			_ = json.NewEncoder(w).Encode(httpx.AccessTokenResponse{Error: "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(httpx.AccessTokenResponse{AccessToken: "accessToken", RefreshToken: "refreshToken", ExpiresIn: 3600})
+	}))
+	t.Cleanup(lwa.Close)
+
+	flow, err := NewFlow(Config{
+		ApplicationID: "amzn1.sp.solution.test",
+		ClientID:      "clientID",
+		ClientSecret:  "clientSecret",
+		CentralURL:    SellerCentralEurope,
+		RedirectURI:   "https://example.com/callback",
+		Draft:         true,
+		TokenURL:      lwa.URL,
+	})
+	require.NoError(t, err)
+	return flow
+}
+
+func TestFlow_AuthorizationURL(t *testing.T) {
+	flow := newTestFlow(t)
+
+	authURL, err := flow.AuthorizationURL()
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://sellercentral-europe.amazon.com/apps/authorize/consent", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "amzn1.sp.solution.test", parsed.Query().Get("application_id"))
+	assert.Equal(t, "beta", parsed.Query().Get("version"))
+	assert.Equal(t, "https://example.com/callback", parsed.Query().Get("redirect_uri"))
+	assert.Len(t, parsed.Query().Get("state"), 48)
+}
+
+func TestFlow_RedirectHandler(t *testing.T) {
+	flow := newTestFlow(t)
+	var authorization *Authorization
+	handler := flow.RedirectHandler(func(w http.ResponseWriter, _ *http.Request, a *Authorization) {
+		authorization = a
+		w.WriteHeader(http.StatusNoContent)
+	}, nil)
+
+	authURL, err := flow.AuthorizationURL()
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	state := parsed.Query().Get("state")
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		wantCode int
+	}{
+		{
+			name:     "Unknown state",
+			query:    url.Values{ParamState: {"unknown"}, ParamOAuthCode: {"valid-code"}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "Missing code",
+			query:    url.Values{ParamState: {state}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "Success",
+			query:    url.Values{ParamState: {state}, ParamOAuthCode: {"valid-code"}, ParamSellingPartnerID: {"A1SELLER"}},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "State is used only once",
+			query:    url.Values{ParamState: {state}, ParamOAuthCode: {"valid-code"}},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?"+tt.query.Encode(), nil))
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+
+	require.NotNil(t, authorization)
+	assert.Equal(t, &Authorization{
+		SellingPartnerID: "A1SELLER",
+		Credentials:      httpx.Credentials{ClientID: "clientID", ClientSecret: "clientSecret", RefreshToken: "refreshToken"},
+	}, authorization)
+}
+
+func TestFlow_CompleteWithRejectedCode(t *testing.T) {
+	flow := newTestFlow(t)
+	require.NoError(t, flow.config.StateStore.Save("state", flow.config.StateStore.(*MemoryStateStore).now().Add(DefaultStateTTL)))
+
+	_, err := flow.Complete("state", "invalid-code", "A1SELLER")
+	var tokenErr *httpx.TokenError
+	assert.ErrorAs(t, err, &tokenErr)
+	assert.Equal(t, "invalid_grant", tokenErr.Code)
+}
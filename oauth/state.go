@@ -0,0 +1,52 @@
+package oauth
+
+import (
+	"sync"
+	"time"
+)
+
+// StateStore keeps the states of pending authorizations. Services with several replicas need
+// a shared implementation, as the redirect may reach another replica.
+type StateStore interface {
+	// Save stores the state until it expires.
+	Save(state string, expiresAt time.Time) error
+	// Consume removes the state and reports whether it existed and was not expired.
+	Consume(state string) (bool, error)
+}
+
+// MemoryStateStore is a StateStore for a single process.
+type MemoryStateStore struct {
+	mu     sync.Mutex
+	states map[string]time.Time
+	now    func() time.Time
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		states: map[string]time.Time{},
+		now:    time.Now,
+	}
+}
+
+func (s *MemoryStateStore) Save(state string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for existing, existingExpiresAt := range s.states {
+		if !now.Before(existingExpiresAt) {
+			delete(s.states, existing)
+		}
+	}
+	s.states[state] = expiresAt
+	return nil
+}
+
+func (s *MemoryStateStore) Consume(state string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.states[state]
+	delete(s.states, state)
+	return ok && s.now().Before(expiresAt), nil
+}
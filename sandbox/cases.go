@@ -0,0 +1,108 @@
+package sandbox
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Documented parameters of the static sandbox, e.g. to call the typed operations of the APIs.
+const (
+	ReportID              = "ID323"
+	BadReportID           = "badReportId1"
+	ReportScheduleID      = "ID323"
+	BadReportScheduleID   = "badReportScheduleId1"
+	ReportDocumentID      = "0356cf79-b8b0-4226-b4b9-0ee058ea5760"
+	BadReportDocumentID   = "badReportDocumentId1"
+	FeedID                = "feedId1"
+	BadFeedID             = "badFeedId1"
+	FeedDocumentID        = "0356cf79-b8b0-4226-b4b9-0ee058ea5760"
+	InputFeedDocumentID   = "3d4e42b5-1d6e-44e8-a89c-2abfca0625bb"
+	OrderID               = "TEST_CASE_200"
+	BadOrderID            = "TEST_CASE_400"
+	OrdersCreatedAfter    = "TEST_CASE_200"
+	BadOrdersCreatedAfter = "TEST_CASE_400"
+	// Timestamp is used for dataStartTime and nextReportCreationTime.
+	Timestamp = "2019-12-10T20:11:24.000Z"
+)
+
+const (
+	reportsAPI  = "reports"
+	feedsAPI    = "feeds"
+	ordersV0API = "ordersV0"
+
+	reportsPathPrefix  = "/reports/2021-06-30"
+	feedsPathPrefix    = "/feeds/2021-06-30"
+	ordersV0PathPrefix = "/orders/v0"
+
+	marketplaceIDs          = `["A1PA6795UKMFR9","ATVPDKIKX0DER"]`
+	feedDocumentContentType = "text/xml; charset=UTF-8"
+)
+
+// The operations of the finances, notifications, tokens and applications APIs and of orders 2026-01-01
+// are not catalogued yet, neither are the regulated order and shipment confirmation operations of orders v0.
+var cases = []Case{
+	// reports 2021-06-30
+	{API: reportsAPI, Operation: "getReports", Method: http.MethodGet, Path: reportsPathPrefix + "/reports",
+		Query:  url.Values{"reportTypes": {"FEE_DISCOUNTS_REPORT,GET_AFN_INVENTORY_DATA"}, "processingStatuses": {"IN_QUEUE,IN_PROGRESS"}},
+		Status: http.StatusOK},
+	{API: reportsAPI, Operation: "getReports", Method: http.MethodGet, Path: reportsPathPrefix + "/reports",
+		Query:  url.Values{"reportTypes": {"FEE_DISCOUNTS_REPORT,GET_AFN_INVENTORY_DATA"}, "processingStatuses": {"BAD_VALUE,IN_PROGRESS"}},
+		Status: http.StatusBadRequest},
+	{API: reportsAPI, Operation: "createReport", Method: http.MethodPost, Path: reportsPathPrefix + "/reports",
+		Body:   `{"reportType":"GET_MERCHANT_LISTINGS_ALL_DATA","dataStartTime":"` + Timestamp + `","marketplaceIds":` + marketplaceIDs + `}`,
+		Status: http.StatusAccepted},
+	{API: reportsAPI, Operation: "createReport", Method: http.MethodPost, Path: reportsPathPrefix + "/reports",
+		Body:   `{"reportType":"BAD_FEE_DISCOUNTS_REPORT","dataStartTime":"` + Timestamp + `","marketplaceIds":` + marketplaceIDs + `}`,
+		Status: http.StatusBadRequest},
+	{API: reportsAPI, Operation: "getReport", Method: http.MethodGet, Path: reportsPathPrefix + "/reports/" + ReportID, Status: http.StatusOK},
+	{API: reportsAPI, Operation: "getReport", Method: http.MethodGet, Path: reportsPathPrefix + "/reports/" + BadReportID, Status: http.StatusBadRequest},
+	{API: reportsAPI, Operation: "cancelReport", Method: http.MethodDelete, Path: reportsPathPrefix + "/reports/" + ReportID, Status: http.StatusOK},
+	{API: reportsAPI, Operation: "cancelReport", Method: http.MethodDelete, Path: reportsPathPrefix + "/reports/" + BadReportID, Status: http.StatusBadRequest},
+	{API: reportsAPI, Operation: "getReportSchedules", Method: http.MethodGet, Path: reportsPathPrefix + "/schedules",
+		Query:  url.Values{"reportTypes": {"FEE_DISCOUNTS_REPORT,GET_FBA_FULFILLMENT_CUSTOMER_TAXES_DATA"}},
+		Status: http.StatusOK},
+	{API: reportsAPI, Operation: "createReportSchedule", Method: http.MethodPost, Path: reportsPathPrefix + "/schedules",
+		Body:   `{"reportType":"FEE_DISCOUNTS_REPORT","period":"PT5M","nextReportCreationTime":"` + Timestamp + `","marketplaceIds":` + marketplaceIDs + `}`,
+		Status: http.StatusCreated},
+	{API: reportsAPI, Operation: "createReportSchedule", Method: http.MethodPost, Path: reportsPathPrefix + "/schedules",
+		Body:   `{"reportType":"BAD_FEE_DISCOUNTS_REPORT","period":"PT5M","nextReportCreationTime":"` + Timestamp + `","marketplaceIds":` + marketplaceIDs + `}`,
+		Status: http.StatusBadRequest},
+	{API: reportsAPI, Operation: "getReportSchedule", Method: http.MethodGet, Path: reportsPathPrefix + "/schedules/" + ReportScheduleID, Status: http.StatusOK},
+	{API: reportsAPI, Operation: "getReportSchedule", Method: http.MethodGet, Path: reportsPathPrefix + "/schedules/" + BadReportScheduleID, Status: http.StatusBadRequest},
+	{API: reportsAPI, Operation: "cancelReportSchedule", Method: http.MethodDelete, Path: reportsPathPrefix + "/schedules/" + ReportScheduleID, Status: http.StatusOK},
+	{API: reportsAPI, Operation: "cancelReportSchedule", Method: http.MethodDelete, Path: reportsPathPrefix + "/schedules/" + BadReportScheduleID, Status: http.StatusBadRequest},
+	{API: reportsAPI, Operation: "getReportDocument", Method: http.MethodGet, Path: reportsPathPrefix + "/documents/" + ReportDocumentID, Status: http.StatusOK},
+	{API: reportsAPI, Operation: "getReportDocument", Method: http.MethodGet, Path: reportsPathPrefix + "/documents/" + BadReportDocumentID, Status: http.StatusBadRequest},
+
+	// feeds 2021-06-30
+	{API: feedsAPI, Operation: "getFeeds", Method: http.MethodGet, Path: feedsPathPrefix + "/feeds",
+		Query:  url.Values{"feedTypes": {"POST_PRODUCT_DATA"}, "pageSize": {"10"}, "processingStatuses": {"CANCELLED,DONE"}},
+		Status: http.StatusOK},
+	{API: feedsAPI, Operation: "createFeed", Method: http.MethodPost, Path: feedsPathPrefix + "/feeds",
+		Body:   `{"feedType":"POST_PRODUCT_DATA","marketplaceIds":["ATVPDKIKX0DER"],"inputFeedDocumentId":"` + InputFeedDocumentID + `"}`,
+		Status: http.StatusAccepted},
+	{API: feedsAPI, Operation: "getFeed", Method: http.MethodGet, Path: feedsPathPrefix + "/feeds/" + FeedID, Status: http.StatusOK},
+	{API: feedsAPI, Operation: "getFeed", Method: http.MethodGet, Path: feedsPathPrefix + "/feeds/" + BadFeedID, Status: http.StatusBadRequest},
+	{API: feedsAPI, Operation: "cancelFeed", Method: http.MethodDelete, Path: feedsPathPrefix + "/feeds/" + FeedID, Status: http.StatusOK},
+	{API: feedsAPI, Operation: "cancelFeed", Method: http.MethodDelete, Path: feedsPathPrefix + "/feeds/" + BadFeedID, Status: http.StatusBadRequest},
+	{API: feedsAPI, Operation: "createFeedDocument", Method: http.MethodPost, Path: feedsPathPrefix + "/documents",
+		Body:   `{"contentType":"` + feedDocumentContentType + `"}`,
+		Status: http.StatusCreated},
+	{API: feedsAPI, Operation: "getFeedDocument", Method: http.MethodGet, Path: feedsPathPrefix + "/documents/" + FeedDocumentID, Status: http.StatusOK},
+
+	// orders v0
+	{API: ordersV0API, Operation: "getOrders", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders",
+		Query:  url.Values{"CreatedAfter": {OrdersCreatedAfter}, "MarketplaceIds": {"ATVPDKIKX0DER"}},
+		Status: http.StatusOK},
+	{API: ordersV0API, Operation: "getOrders", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders",
+		Query:  url.Values{"CreatedAfter": {BadOrdersCreatedAfter}, "MarketplaceIds": {"ATVPDKIKX0DER"}},
+		Status: http.StatusBadRequest},
+	{API: ordersV0API, Operation: "getOrder", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + OrderID, Status: http.StatusOK},
+	{API: ordersV0API, Operation: "getOrder", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + BadOrderID, Status: http.StatusBadRequest},
+	{API: ordersV0API, Operation: "getOrderBuyerInfo", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + OrderID + "/buyerInfo", Status: http.StatusOK},
+	{API: ordersV0API, Operation: "getOrderBuyerInfo", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + BadOrderID + "/buyerInfo", Status: http.StatusBadRequest},
+	{API: ordersV0API, Operation: "getOrderAddress", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + OrderID + "/address", Status: http.StatusOK},
+	{API: ordersV0API, Operation: "getOrderAddress", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + BadOrderID + "/address", Status: http.StatusBadRequest},
+	{API: ordersV0API, Operation: "getOrderItems", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + OrderID + "/orderItems", Status: http.StatusOK},
+	{API: ordersV0API, Operation: "getOrderItems", Method: http.MethodGet, Path: ordersV0PathPrefix + "/orders/" + BadOrderID + "/orderItems", Status: http.StatusBadRequest},
+}
@@ -0,0 +1,76 @@
+// Package sandbox catalogues the static sandbox requests of the implemented operations.
+// The static sandbox returns a canned response only for requests which match one of the
+// documented requests exactly, all other requests fail. The values are taken from the
+// x-amzn-api-sandbox sections of the SP-API models.
+package sandbox
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// Case is a documented static sandbox request and the status of its canned response.
+type Case struct {
+	// API is the name of the API, e.g. reports.
+	API string
+	// Operation is the operation ID of the model, e.g. createReport.
+	Operation string
+	Method    string
+	// Path contains the documented path parameters.
+	Path  string
+	Query url.Values
+	// Body is the JSON body of the request, empty if it has none.
+	Body string
+	// Status is the HTTP status of the canned response.
+	Status int
+}
+
+// NewRequest creates the request for the sandbox endpoint of the region. The access token must be
+// added by the caller, e.g. by sending it with httpx.Client. Endpoints without a sandbox are rejected.
+func (c Case) NewRequest(endpoint constants.Endpoint) (*http.Request, error) {
+	sandbox, ok := endpoint.Sandbox()
+	if !ok {
+		return nil, fmt.Errorf("endpoint %s has no sandbox", endpoint)
+	}
+	u, err := url.Parse(string(sandbox) + c.Path)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = c.Query.Encode()
+
+	var body io.Reader
+	if c.Body != "" {
+		body = strings.NewReader(c.Body)
+	}
+	req, err := http.NewRequest(c.Method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// Cases returns all static sandbox requests.
+func Cases() []Case {
+	result := make([]Case, len(cases))
+	copy(result, cases)
+	return result
+}
+
+// CasesOf returns the static sandbox requests of the operation, e.g. createReport.
+func CasesOf(operation string) []Case {
+	var result []Case
+	for _, c := range cases {
+		if c.Operation == operation {
+			result = append(result, c)
+		}
+	}
+	return result
+}
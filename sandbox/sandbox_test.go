@@ -0,0 +1,81 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCase_NewRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		endpoint  constants.Endpoint
+		wantURL   string
+		wantBody  bool
+	}{
+		{
+			name:      "Production endpoint is replaced",
+			operation: "getReport",
+			endpoint:  constants.Europe,
+			wantURL:   "https://sandbox.sellingpartnerapi-eu.amazon.com/reports/2021-06-30/reports/ID323",
+		},
+		{
+			name:      "Query parameters",
+			operation: "getOrders",
+			endpoint:  constants.SandboxNorthAmerica,
+			wantURL:   "https://sandbox.sellingpartnerapi-na.amazon.com/orders/v0/orders?CreatedAfter=TEST_CASE_200&MarketplaceIds=ATVPDKIKX0DER",
+		},
+		{
+			name:      "Body",
+			operation: "createFeedDocument",
+			endpoint:  constants.FarEast,
+			wantURL:   "https://sandbox.sellingpartnerapi-fe.amazon.com/feeds/2021-06-30/documents",
+			wantBody:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cases := CasesOf(tt.operation)
+			require.NotEmpty(t, cases)
+			c := cases[0]
+
+			req, err := c.NewRequest(tt.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, c.Method, req.Method)
+			assert.Equal(t, tt.wantURL, req.URL.String())
+			if !tt.wantBody {
+				assert.Nil(t, req.Body)
+				return
+			}
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, c.Body, string(body))
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		})
+	}
+}
+
+func TestCase_NewRequestWithoutSandbox(t *testing.T) {
+	cases := CasesOf("getReport")
+	require.NotEmpty(t, cases)
+
+	_, err := cases[0].NewRequest("https://proxy.example.com")
+	assert.EqualError(t, err, "endpoint https://proxy.example.com has no sandbox")
+}
+
+func TestCases(t *testing.T) {
+	for _, c := range Cases() {
+		assert.NotEmpty(t, c.API, c.Operation)
+		assert.Contains(t, []string{http.MethodGet, http.MethodPost, http.MethodDelete}, c.Method, c.Operation)
+		assert.NotZero(t, c.Status, c.Operation)
+		if c.Body != "" {
+			assert.True(t, json.Valid([]byte(c.Body)), c.Operation)
+		}
+	}
+}
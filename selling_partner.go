@@ -1,11 +1,16 @@
 package sp_api
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/applications"
 	"github.com/fond-of-vertigo/amazon-sp-api/apis/feeds"
 	"github.com/fond-of-vertigo/amazon-sp-api/apis/finances"
+	financesv20240619 "github.com/fond-of-vertigo/amazon-sp-api/apis/finances/v20240619"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/notifications"
 	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	ordersv0 "github.com/fond-of-vertigo/amazon-sp-api/apis/orders/v0"
 	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
 	"github.com/fond-of-vertigo/amazon-sp-api/apis/tokens"
 	"github.com/fond-of-vertigo/amazon-sp-api/constants"
@@ -20,37 +25,73 @@ type Config struct {
 	Endpoint     constants.Endpoint
 	Log          logger.Logger
 	HTTPClient   *http.Client
+	// Marketplace determines the Endpoint if Endpoint is not set.
+	Marketplace constants.MarketplaceID
+	// CredentialsProvider replaces ClientID, ClientSecret and RefreshToken if set,
+	// e.g. to rotate the client secret without a restart.
+	CredentialsProvider httpx.CredentialsProvider
+	// AccessTokenSource replaces the token updater which fetches access tokens from LWA if set.
+	AccessTokenSource httpx.AccessTokenSource
+	// TokenURL overrides the LWA endpoint for access token requests.
+	TokenURL string
+	// TokenStore shares the access token with other replicas, so that only one of them refreshes it.
+	TokenStore httpx.TokenStore
+	// AutoRestrictedDataToken enables the automatic acquisition of Restricted Data Tokens (RDTs)
+	// for restricted operations which are called without an explicit RDT.
+	AutoRestrictedDataToken bool
+	// Sandbox sends all calls to the sandbox endpoint of the region, see package sandbox for
+	// the requests which the static sandbox responds to.
+	Sandbox bool
 }
 
 type Client struct {
-	httpClient  *httpx.Client
-	FinancesAPI *finances.API
-	FeedsAPI    *feeds.API
-	OrdersAPI   *orders.API
-	ReportsAPI  *reports.API
-	TokenAPI    *tokens.API
+	httpClient              *httpx.Client
+	ApplicationsAPI         *applications.API
+	FinancesAPI             *finances.API
+	FinancesTransactionsAPI *financesv20240619.API
+	FeedsAPI                *feeds.API
+	NotificationsAPI        *notifications.API
+	OrdersAPI               *orders.API
+	OrdersV0API             *ordersv0.API
+	ReportsAPI              *reports.API
+	TokenAPI                *tokens.API
 }
 
-// Close stops the TokenUpdater thread
+// Close stops the TokenUpdater thread. Calling Close more than once has no effect.
 func (s *Client) Close() {
 	s.httpClient.Close()
 }
 
+// TokenHealth returns the health state of the access token, e.g. for readiness checks.
+// The second return value is false if a custom AccessTokenSource does not report its health.
+func (s *Client) TokenHealth() (httpx.TokenHealth, bool) {
+	return s.httpClient.TokenHealth()
+}
+
 func NewClient(config Config) (*Client, error) {
+	endpoint, err := config.endpoint()
+	if err != nil {
+		return nil, err
+	}
+
 	hc := config.HTTPClient
 	if config.HTTPClient == nil {
 		hc = http.DefaultClient
 	}
 
 	clientConfig := httpx.ClientConfig{
-		HTTPClient: hc,
-		Endpoint:   config.Endpoint,
+		HTTPClient:        hc,
+		Endpoint:          endpoint,
+		AccessTokenSource: config.AccessTokenSource,
 		TokenUpdaterConfig: httpx.TokenUpdaterConfig{
-			RefreshToken: config.RefreshToken,
-			ClientID:     config.ClientID,
-			ClientSecret: config.ClientSecret,
-			HTTPClient:   hc,
-			Logger:       config.Log,
+			RefreshToken:        config.RefreshToken,
+			ClientID:            config.ClientID,
+			ClientSecret:        config.ClientSecret,
+			CredentialsProvider: config.CredentialsProvider,
+			TokenURL:            config.TokenURL,
+			TokenStore:          config.TokenStore,
+			HTTPClient:          hc,
+			Logger:              config.Log,
 		},
 	}
 
@@ -59,12 +100,45 @@ func NewClient(config Config) (*Client, error) {
 		return nil, err
 	}
 
+	tokenAPI := tokens.NewAPI(httpxClient)
+	if config.AutoRestrictedDataToken {
+		httpxClient.SetRestrictedDataTokenProvider(tokens.NewRestrictedDataTokenCache(tokenAPI))
+	}
+
 	return &Client{
-		httpClient:  httpxClient,
-		FinancesAPI: finances.NewAPI(httpxClient),
-		FeedsAPI:    feeds.NewAPI(httpxClient),
-		OrdersAPI:   orders.NewAPI(httpxClient),
-		ReportsAPI:  reports.NewAPI(httpxClient),
-		TokenAPI:    tokens.NewAPI(httpxClient),
+		httpClient:              httpxClient,
+		ApplicationsAPI:         applications.NewAPI(httpxClient),
+		FinancesAPI:             finances.NewAPI(httpxClient),
+		FinancesTransactionsAPI: financesv20240619.NewAPI(httpxClient),
+		FeedsAPI:                feeds.NewAPI(httpxClient),
+		NotificationsAPI:        notifications.NewAPI(httpxClient),
+		OrdersAPI:               orders.NewAPI(httpxClient),
+		OrdersV0API:             ordersv0.NewAPI(httpxClient),
+		ReportsAPI:              reports.NewAPI(httpxClient),
+		TokenAPI:                tokenAPI,
 	}, nil
 }
+
+// endpoint returns the Endpoint or the endpoint of the Marketplace, or its sandbox endpoint if Sandbox is set.
+func (c *Config) endpoint() (constants.Endpoint, error) {
+	endpoint := c.Endpoint
+	if c.Marketplace != "" {
+		marketplace, ok := constants.MarketplaceByID(c.Marketplace)
+		if !ok {
+			return "", fmt.Errorf("unknown marketplace %s", c.Marketplace)
+		}
+		if c.Endpoint != "" && c.Endpoint != marketplace.Endpoint {
+			return "", fmt.Errorf("marketplace %s is not served by endpoint %s", marketplace.Name, c.Endpoint)
+		}
+		endpoint = marketplace.Endpoint
+	}
+	if !c.Sandbox {
+		return endpoint, nil
+	}
+
+	sandbox, ok := endpoint.Sandbox()
+	if !ok {
+		return "", fmt.Errorf("endpoint %s has no sandbox", endpoint)
+	}
+	return sandbox, nil
+}
@@ -0,0 +1,74 @@
+package sp_api
+
+import (
+	"testing"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_endpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		want    constants.Endpoint
+		wantErr bool
+	}{
+		{
+			name:   "Endpoint",
+			config: Config{Endpoint: constants.Europe},
+			want:   constants.Europe,
+		},
+		{
+			name:   "Marketplace",
+			config: Config{Marketplace: constants.Japan},
+			want:   constants.FarEast,
+		},
+		{
+			name:   "Matching endpoint and marketplace",
+			config: Config{Endpoint: constants.Europe, Marketplace: constants.Germany},
+			want:   constants.Europe,
+		},
+		{
+			name:    "Conflicting endpoint and marketplace",
+			config:  Config{Endpoint: constants.Europe, Marketplace: constants.UnitedStatesOfAmerica},
+			wantErr: true,
+		},
+		{
+			name:   "Sandbox of endpoint",
+			config: Config{Endpoint: constants.NorthAmerica, Sandbox: true},
+			want:   constants.SandboxNorthAmerica,
+		},
+		{
+			name:   "Sandbox of marketplace",
+			config: Config{Marketplace: constants.Germany, Sandbox: true},
+			want:   constants.SandboxEurope,
+		},
+		{
+			name:   "Sandbox endpoint",
+			config: Config{Endpoint: constants.SandboxFarEast, Sandbox: true},
+			want:   constants.SandboxFarEast,
+		},
+		{
+			name:    "Custom endpoint without sandbox",
+			config:  Config{Endpoint: "http://localhost:8080", Sandbox: true},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown marketplace",
+			config:  Config{Marketplace: "UNKNOWN"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.endpoint()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package spapitest
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/feeds"
+)
+
+// FeedScript scripts the processing of the feeds of a type. A feed is created with the first
+// status and every getFeed call advances it to the next one, it stays in the last one.
+type FeedScript struct {
+	// Statuses defaults to IN_QUEUE, IN_PROGRESS and DONE.
+	Statuses []feeds.ProcessingStatus
+	// ResultDocument is the content of the processing report, which is created with the status DONE.
+	ResultDocument []byte
+}
+
+type feedState struct {
+	feed   feeds.Feed
+	script FeedScript
+	step   int
+}
+
+// ScriptFeed sets the script of all feeds of the type which are created afterwards.
+func (s *Server) ScriptFeed(feedType string, script FeedScript) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.feedScripts[feedType] = script
+}
+
+// UploadedFeedDocument returns the content which was uploaded to the URL of the feed document.
+// The second return value is false if nothing was uploaded.
+func (s *Server) UploadedFeedDocument(feedDocumentID string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, ok := s.documents[feedDocumentID]
+	if !ok || !doc.uploaded {
+		return nil, false
+	}
+	return doc.content, true
+}
+
+func (s *Server) handleGetFeeds(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query, offset, ok := s.pageQuery(r.URL.Query(), "nextToken")
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid nextToken")
+		return
+	}
+	size, err := pageSize(query, "pageSize", 10)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+
+	feedTypes := commaValues(query, "feedTypes")
+	statuses := commaValues(query, "processingStatuses")
+	var matching []feeds.Feed
+	for _, id := range s.feedIDs {
+		feed := s.feeds[id].feed
+		if len(feedTypes) > 0 && !contains(feedTypes, feed.FeedType) {
+			continue
+		}
+		if len(statuses) > 0 && !contains(statuses, string(feed.ProcessingStatus)) {
+			continue
+		}
+		matching = append(matching, feed)
+	}
+
+	result, nextToken := page(s, matching, query, offset, size)
+	writeJSON(w, http.StatusOK, feeds.GetFeedsResponse{Feeds: result, NextToken: nextToken})
+}
+
+func (s *Server) handleCreateFeedDocument(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	specification := &feeds.CreateFeedDocumentSpecification{}
+	if !decodeBody(w, r, specification) {
+		return
+	}
+	if specification.ContentType == "" {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "contentType is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	documentID := s.newID("feedDocument")
+	s.documents[documentID] = &document{}
+	writeJSON(w, http.StatusCreated, feeds.CreateFeedDocumentResponse{
+		FeedDocumentId: documentID,
+		Url:            s.documentURL(documentID),
+	})
+}
+
+func (s *Server) handleUploadDocument(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, ok := s.documents[params["documentId"]]
+	if !ok {
+		http.Error(w, "NoSuchKey", http.StatusNotFound)
+		return
+	}
+	doc.content = content
+	doc.uploaded = true
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleCreateFeed(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	specification := &feeds.CreateFeedSpecification{}
+	if !decodeBody(w, r, specification) {
+		return
+	}
+	if specification.FeedType == "" || len(specification.MarketplaceIDs) == 0 {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "feedType and marketplaceIds are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if doc, ok := s.documents[specification.InputFeedDocumentId]; !ok || !doc.uploaded {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "No content was uploaded for inputFeedDocumentId "+specification.InputFeedDocumentId)
+		return
+	}
+
+	script := s.feedScripts[specification.FeedType]
+	if len(script.Statuses) == 0 {
+		script.Statuses = []feeds.ProcessingStatus{feeds.ProcessingStatusInQueue, feeds.ProcessingStatusInProgress, feeds.ProcessingStatusDone}
+	}
+	state := &feedState{
+		feed: feeds.Feed{
+			FeedId:         s.newID("feed"),
+			FeedType:       specification.FeedType,
+			MarketplaceIDs: specification.MarketplaceIDs,
+			CreatedTime:    s.now().UTC(),
+		},
+		script: script,
+	}
+	s.setFeedStatus(state, script.Statuses[0])
+	s.feedIDs = append(s.feedIDs, state.feed.FeedId)
+	s.feeds[state.feed.FeedId] = state
+
+	writeJSON(w, http.StatusAccepted, feeds.CreateFeedResponse{FeedId: state.feed.FeedId})
+}
+
+func (s *Server) handleGetFeed(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.feeds[params["feedId"]]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "NotFound", "Feed not found: "+params["feedId"])
+		return
+	}
+	if state.feed.ProcessingStatus != feeds.ProcessingStatusCanceled && state.step < len(state.script.Statuses)-1 {
+		state.step++
+		s.setFeedStatus(state, state.script.Statuses[state.step])
+	}
+	writeJSON(w, http.StatusOK, state.feed)
+}
+
+func (s *Server) handleCancelFeed(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.feeds[params["feedId"]]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "NotFound", "Feed not found: "+params["feedId"])
+		return
+	}
+	if state.feed.ProcessingStatus != feeds.ProcessingStatusInQueue {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Only feeds in queue can be cancelled")
+		return
+	}
+	s.setFeedStatus(state, feeds.ProcessingStatusCanceled)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleGetFeedDocument(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	documentID := params["feedDocumentId"]
+	if _, ok := s.documents[documentID]; !ok {
+		writeErrors(w, http.StatusNotFound, "NotFound", "Feed document not found: "+documentID)
+		return
+	}
+	writeJSON(w, http.StatusOK, feeds.FeedDocument{
+		FeedDocumentId: documentID,
+		Url:            s.documentURL(documentID),
+	})
+}
+
+// setFeedStatus updates the status and the processing times and creates the result document of
+// a processed feed. The caller must hold s.mu.
+func (s *Server) setFeedStatus(state *feedState, status feeds.ProcessingStatus) {
+	now := s.now().UTC()
+	feed := &state.feed
+	feed.ProcessingStatus = status
+
+	switch status {
+	case feeds.ProcessingStatusInProgress:
+		feed.ProcessingStartTime = &now
+	case feeds.ProcessingStatusDone:
+		if feed.ProcessingStartTime == nil {
+			feed.ProcessingStartTime = &now
+		}
+		feed.ProcessingEndTime = &now
+		if feed.ResultFeedDocumentId == nil {
+			documentID := s.newID("feedDocument")
+			s.documents[documentID] = &document{content: state.script.ResultDocument}
+			feed.ResultFeedDocumentId = &documentID
+		}
+	case feeds.ProcessingStatusCanceled, feeds.ProcessingStatusFatal:
+		feed.ProcessingEndTime = &now
+	}
+}
@@ -0,0 +1,64 @@
+package spapitest
+
+import (
+	"net/http"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/finances"
+)
+
+// AddFinancialEventGroups adds groups which are returned by listFinancialEventGroups, paginated by
+// MaxResultsPerPage.
+func (s *Server) AddFinancialEventGroups(groups ...finances.FinancialEventGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.financialEventGroups = append(s.financialEventGroups, groups...)
+}
+
+// SetFinancialEventPages sets the pages which are returned by listFinancialEvents. Every call without
+// NextToken starts with the first page, so each time window of finances.FinancialEventsIterator
+// receives all pages.
+func (s *Server) SetFinancialEventPages(pages ...finances.FinancialEvents) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.financialEventPages = pages
+}
+
+func (s *Server) handleListFinancialEventGroups(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query, offset, ok := s.pageQuery(r.URL.Query(), "NextToken")
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid NextToken")
+		return
+	}
+	size, err := pageSize(query, "MaxResultsPerPage", 100)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+
+	result, nextToken := page(s, s.financialEventGroups, query, offset, size)
+	writeJSON(w, http.StatusOK, finances.ListFinancialEventGroupsResponse{Payload: &finances.ListFinancialEventGroupsPayload{
+		FinancialEventGroupList: result,
+		NextToken:               nextToken,
+	}})
+}
+
+func (s *Server) handleListFinancialEvents(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query, offset, ok := s.pageQuery(r.URL.Query(), "NextToken")
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid NextToken")
+		return
+	}
+
+	payload := &finances.ListFinancialEventsPayload{FinancialEvents: &finances.FinancialEvents{}}
+	if result, nextToken := page(s, s.financialEventPages, query, offset, 1); len(result) > 0 {
+		payload.FinancialEvents = &result[0]
+		payload.NextToken = nextToken
+	}
+	writeJSON(w, http.StatusOK, finances.ListFinancialEventsResponse{Payload: payload})
+}
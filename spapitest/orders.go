@@ -0,0 +1,154 @@
+package spapitest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	ordersv0 "github.com/fond-of-vertigo/amazon-sp-api/apis/orders/v0"
+)
+
+// AddOrders adds orders which are returned by searchOrders and getOrder.
+func (s *Server) AddOrders(o ...orders.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders = append(s.orders, o...)
+}
+
+// AddOrdersV0 adds orders which are returned by getOrders and getOrder of orders v0.
+func (s *Server) AddOrdersV0(o ...ordersv0.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ordersV0 = append(s.ordersV0, o...)
+}
+
+func (s *Server) handleSearchOrders(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query, offset, ok := s.pageQuery(r.URL.Query(), "paginationToken")
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid paginationToken")
+		return
+	}
+	size, err := pageSize(query, "maxResultsPerPage", 100)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+	filter, err := parseTimeFilter(query, "createdAfter", "createdBefore", "lastUpdatedAfter", "lastUpdatedBefore")
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+
+	marketplaceIDs := commaValues(query, "marketplaceIds")
+	var matching []orders.Order
+	for _, order := range s.orders {
+		if len(marketplaceIDs) > 0 && !contains(marketplaceIDs, order.SalesChannel.MarketplaceID) {
+			continue
+		}
+		if filter.matches(order.CreatedTime, order.LastUpdatedTime) {
+			matching = append(matching, order)
+		}
+	}
+
+	result, nextToken := page(s, matching, query, offset, size)
+	response := orders.SearchOrdersResponse{Orders: result}
+	if nextToken != nil {
+		response.Pagination = &orders.Pagination{NextToken: nextToken}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleGetOrder(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, order := range s.orders {
+		if order.OrderID == params["orderId"] {
+			writeJSON(w, http.StatusOK, orders.GetOrderResponse{Order: order})
+			return
+		}
+	}
+	writeErrors(w, http.StatusNotFound, "NotFound", "Order not found: "+params["orderId"])
+}
+
+func (s *Server) handleGetOrdersV0(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query, offset, ok := s.pageQuery(r.URL.Query(), "NextToken")
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid NextToken")
+		return
+	}
+	size, err := pageSize(query, "MaxResultsPerPage", 100)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+	filter, err := parseTimeFilter(query, "CreatedAfter", "CreatedBefore", "LastUpdatedAfter", "LastUpdatedBefore")
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+
+	marketplaceIDs := commaValues(query, "MarketplaceIds")
+	var matching []ordersv0.Order
+	for _, order := range s.ordersV0 {
+		if len(marketplaceIDs) > 0 && (order.MarketplaceId == nil || !contains(marketplaceIDs, *order.MarketplaceId)) {
+			continue
+		}
+		if filter.matches(order.PurchaseDate, order.LastUpdateDate) {
+			matching = append(matching, order)
+		}
+	}
+
+	result, nextToken := page(s, matching, query, offset, size)
+	writeJSON(w, http.StatusOK, ordersv0.GetOrdersResponse{Payload: &ordersv0.OrdersList{Orders: result, NextToken: nextToken}})
+}
+
+func (s *Server) handleGetOrderV0(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, order := range s.ordersV0 {
+		if order.AmazonOrderId == params["orderId"] {
+			order := order
+			writeJSON(w, http.StatusOK, ordersv0.GetOrderResponse{Payload: &order})
+			return
+		}
+	}
+	writeErrors(w, http.StatusNotFound, "NotFound", "Order not found: "+params["orderId"])
+}
+
+// timeFilter contains the creation and update time filters of an order search, zero times are not set.
+type timeFilter struct {
+	createdAfter, createdBefore, lastUpdatedAfter, lastUpdatedBefore time.Time
+}
+
+func parseTimeFilter(query url.Values, params ...string) (timeFilter, error) {
+	var times [4]time.Time
+	for i, param := range params {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return timeFilter{}, fmt.Errorf("invalid %s %q", param, value)
+		}
+		times[i] = t
+	}
+	return timeFilter{createdAfter: times[0], createdBefore: times[1], lastUpdatedAfter: times[2], lastUpdatedBefore: times[3]}, nil
+}
+
+func (f timeFilter) matches(created time.Time, lastUpdated time.Time) bool {
+	return (f.createdAfter.IsZero() || !created.Before(f.createdAfter)) &&
+		(f.createdBefore.IsZero() || created.Before(f.createdBefore)) &&
+		(f.lastUpdatedAfter.IsZero() || !lastUpdated.Before(f.lastUpdatedAfter)) &&
+		(f.lastUpdatedBefore.IsZero() || lastUpdated.Before(f.lastUpdatedBefore))
+}
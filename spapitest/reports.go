@@ -0,0 +1,221 @@
+package spapitest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+)
+
+// ReportScript scripts the processing of the reports of a type. A report is created with the first
+// status and every getReport call advances it to the next one, it stays in the last one.
+type ReportScript struct {
+	// Statuses defaults to IN_QUEUE, IN_PROGRESS and DONE.
+	Statuses []constants.ProcessingStatus
+	// Document is the content of the report document, which is created with the status DONE or FATAL.
+	Document []byte
+	// Compress serves the document compressed with GZIP.
+	Compress bool
+}
+
+type reportState struct {
+	report reports.ReportModel
+	script ReportScript
+	step   int
+}
+
+// document is a report or feed document which is served at its presigned URL.
+type document struct {
+	content  []byte
+	compress bool
+	uploaded bool
+}
+
+// ScriptReport sets the script of all reports of the type which are created afterwards.
+func (s *Server) ScriptReport(reportType reports.Type, script ReportScript) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reportScripts[reportType] = script
+}
+
+func (s *Server) handleGetReports(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query, offset, ok := s.pageQuery(r.URL.Query(), "nextToken")
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid nextToken")
+		return
+	}
+	size, err := pageSize(query, "pageSize", 10)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+
+	reportTypes := commaValues(query, "reportTypes")
+	statuses := commaValues(query, "processingStatuses")
+	var matching []reports.ReportModel
+	for _, id := range s.reportIDs {
+		report := s.reports[id].report
+		if len(reportTypes) > 0 && !contains(reportTypes, string(report.ReportType)) {
+			continue
+		}
+		if len(statuses) > 0 && !contains(statuses, string(report.ProcessingStatus)) {
+			continue
+		}
+		matching = append(matching, report)
+	}
+
+	result, nextToken := page(s, matching, query, offset, size)
+	writeJSON(w, http.StatusOK, reports.GetReportsResponse{Reports: result, NextToken: nextToken})
+}
+
+func (s *Server) handleCreateReport(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	specification := &reports.CreateReportSpecification{}
+	if !decodeBody(w, r, specification) {
+		return
+	}
+	if specification.ReportType == "" || len(specification.MarketplaceIDs) == 0 {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "reportType and marketplaceIds are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script := s.reportScripts[specification.ReportType]
+	if len(script.Statuses) == 0 {
+		script.Statuses = []constants.ProcessingStatus{constants.InQueue, constants.InProgress, constants.Done}
+	}
+	state := &reportState{
+		report: reports.ReportModel{
+			MarketplaceIDs: specification.MarketplaceIDs,
+			ReportID:       s.newID("report"),
+			ReportType:     specification.ReportType,
+			CreatedTime:    s.now().UTC(),
+		},
+		script: script,
+	}
+	if !specification.DataStartTime.IsZero() {
+		state.report.DataStartTime = &specification.DataStartTime.Time
+	}
+	if !specification.DataEndTime.IsZero() {
+		state.report.DataEndTime = &specification.DataEndTime.Time
+	}
+	s.setReportStatus(state, script.Statuses[0])
+	s.reportIDs = append(s.reportIDs, state.report.ReportID)
+	s.reports[state.report.ReportID] = state
+
+	writeJSON(w, http.StatusAccepted, reports.CreateReportResponse{ReportID: state.report.ReportID})
+}
+
+func (s *Server) handleGetReport(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.reports[params["reportId"]]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "NotFound", "Report not found: "+params["reportId"])
+		return
+	}
+	if state.report.ProcessingStatus != constants.Cancelled && state.step < len(state.script.Statuses)-1 {
+		state.step++
+		s.setReportStatus(state, state.script.Statuses[state.step])
+	}
+	writeJSON(w, http.StatusOK, reports.GetReportResponse{ReportModel: state.report})
+}
+
+func (s *Server) handleCancelReport(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.reports[params["reportId"]]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "NotFound", "Report not found: "+params["reportId"])
+		return
+	}
+	if state.report.ProcessingStatus != constants.InQueue {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Only reports in queue can be cancelled")
+		return
+	}
+	s.setReportStatus(state, constants.Cancelled)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleGetReportDocument(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	documentID := params["reportDocumentId"]
+	doc, ok := s.documents[documentID]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "NotFound", "Report document not found: "+documentID)
+		return
+	}
+	response := reports.GetReportDocumentResponse{ReportDocument: reports.ReportDocument{
+		ReportDocumentID: documentID,
+		Url:              s.documentURL(documentID),
+	}}
+	if doc.compress {
+		algorithm := "GZIP"
+		response.CompressionAlgorithm = &algorithm
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// setReportStatus updates the status and the processing times and creates the document of a
+// finished report. The caller must hold s.mu.
+func (s *Server) setReportStatus(state *reportState, status constants.ProcessingStatus) {
+	now := s.now().UTC()
+	report := &state.report
+	report.ProcessingStatus = status
+
+	switch status {
+	case constants.InProgress:
+		report.ProcessingStartTime = &now
+	case constants.Done, constants.Fatal:
+		if report.ProcessingStartTime == nil {
+			report.ProcessingStartTime = &now
+		}
+		report.ProcessingEndTime = &now
+		if report.ReportDocumentID == nil {
+			documentID := s.newID("reportDocument")
+			s.documents[documentID] = &document{content: state.script.Document, compress: state.script.Compress}
+			report.ReportDocumentID = &documentID
+		}
+	case constants.Cancelled:
+		report.ProcessingEndTime = &now
+	}
+}
+
+// documentURL returns the presigned URL of a document.
+func (s *Server) documentURL(documentID string) string {
+	return s.URL + "/spapitest/documents/" + documentID + "?X-Amz-Expires=300&X-Amz-Signature=spapitest"
+}
+
+func (s *Server) handleDownloadDocument(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	doc, ok := s.documents[params["documentId"]]
+	var content []byte
+	var compress bool
+	if ok {
+		content, compress = doc.content, doc.compress
+	}
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "NoSuchKey", http.StatusNotFound)
+		return
+	}
+
+	if compress {
+		buf := &bytes.Buffer{}
+		zw := gzip.NewWriter(buf)
+		_, _ = zw.Write(content)
+		_ = zw.Close()
+		content = buf.Bytes()
+	}
+	_, _ = w.Write(content)
+}
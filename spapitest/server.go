@@ -0,0 +1,467 @@
+// Package spapitest provides an in-process fake of the SP-API and LWA for tests.
+//
+//	s := spapitest.NewServer(t)
+//	s.ScriptReport(reports.FBAAmazonFulfilledInventoryReport, spapitest.ReportScript{Document: []byte("sku\tqty")})
+//	client := s.NewClient(sp_api.Config{})
+//	resp, err := client.ReportsAPI.CreateReport(...)
+//
+// Operations are identified by the operation ID of the SP-API models, e.g. createReport. Every
+// operation can be throttled and fail on demand, all requests are recorded for assertions.
+package spapitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sp_api "github.com/fond-of-vertigo/amazon-sp-api"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/finances"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	ordersv0 "github.com/fond-of-vertigo/amazon-sp-api/apis/orders/v0"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/fond-of-vertigo/logger"
+)
+
+// Credentials and tokens which are accepted and issued by the server.
+const (
+	ClientID            = "spapitest-client-id"
+	ClientSecret        = "spapitest-client-secret"
+	RefreshToken        = "spapitest-refresh-token"
+	AccessToken         = "spapitest-access-token"
+	RestrictedDataToken = "spapitest-restricted-data-token"
+)
+
+// TokenPath is the path of the LWA emulation.
+const TokenPath = "/auth/o2/token"
+
+// Operations of the emulated APIs, besides OperationToken and the document transfers,
+// they are the operation IDs of the SP-API models.
+const (
+	OperationToken                     = "token"
+	OperationCreateRestrictedDataToken = "createRestrictedDataToken"
+	OperationGetReports                = "getReports"
+	OperationCreateReport              = "createReport"
+	OperationGetReport                 = "getReport"
+	OperationCancelReport              = "cancelReport"
+	OperationGetReportDocument         = "getReportDocument"
+	OperationGetFeeds                  = "getFeeds"
+	OperationCreateFeed                = "createFeed"
+	OperationGetFeed                   = "getFeed"
+	OperationCancelFeed                = "cancelFeed"
+	OperationCreateFeedDocument        = "createFeedDocument"
+	OperationGetFeedDocument           = "getFeedDocument"
+	OperationSearchOrders              = "searchOrders"
+	OperationGetOrder                  = "getOrder"
+	OperationGetOrdersV0               = "getOrdersV0"
+	OperationGetOrderV0                = "getOrderV0"
+	OperationListFinancialEventGroups  = "listFinancialEventGroups"
+	OperationListFinancialEvents       = "listFinancialEvents"
+	// OperationDownloadDocument is the download of a report or feed document from its presigned URL.
+	OperationDownloadDocument = "downloadDocument"
+	// OperationUploadDocument is the upload of a feed document to its presigned URL.
+	OperationUploadDocument = "uploadDocument"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Operation string
+	Method    string
+	Path      string
+	// Params are the path parameters, e.g. reportId.
+	Params map[string]string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Failure is the response of a failing operation.
+type Failure struct {
+	Status int
+	Errors []apis.Error
+}
+
+// Server emulates LWA and the implemented operations of the SP-API.
+type Server struct {
+	// URL is the endpoint of the SP-API and LWA emulation.
+	URL string
+
+	tb     testing.TB
+	server *httptest.Server
+	routes []route
+	now    func() time.Time
+
+	mu         sync.Mutex
+	requests   []Request
+	throttles  map[string]int
+	failures   map[string][]Failure
+	lastID     int
+	pageTokens map[string]pageToken
+
+	reportIDs     []string
+	reports       map[string]*reportState
+	reportScripts map[reports.Type]ReportScript
+	feedIDs       []string
+	feeds         map[string]*feedState
+	feedScripts   map[string]FeedScript
+	documents     map[string]*document
+
+	orders               []orders.Order
+	ordersV0             []ordersv0.Order
+	financialEventGroups []finances.FinancialEventGroup
+	financialEventPages  []finances.FinancialEvents
+}
+
+type route struct {
+	operation string
+	method    string
+	path      string
+	segments  []string
+	// rateLimit is returned in the x-amzn-RateLimit-Limit header, zero omits it
+	rateLimit float64
+	// public routes do not require an access token
+	public  bool
+	handler func(w http.ResponseWriter, r *http.Request, params map[string]string)
+}
+
+type pageToken struct {
+	query  url.Values
+	offset int
+}
+
+// NewServer starts a Server which is closed by the cleanup of tb.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+	s := &Server{
+		tb:            tb,
+		now:           time.Now,
+		throttles:     map[string]int{},
+		failures:      map[string][]Failure{},
+		pageTokens:    map[string]pageToken{},
+		reports:       map[string]*reportState{},
+		reportScripts: map[reports.Type]ReportScript{},
+		feeds:         map[string]*feedState{},
+		feedScripts:   map[string]FeedScript{},
+		documents:     map[string]*document{},
+	}
+	s.routes = []route{
+		{operation: OperationToken, method: http.MethodPost, path: TokenPath, public: true, handler: s.handleToken},
+		{operation: OperationCreateRestrictedDataToken, method: http.MethodPost, path: "/tokens/2021-03-01/restrictedDataToken", rateLimit: 1, handler: s.handleCreateRestrictedDataToken},
+		{operation: OperationGetReports, method: http.MethodGet, path: "/reports/2021-06-30/reports", rateLimit: 0.0222, handler: s.handleGetReports},
+		{operation: OperationCreateReport, method: http.MethodPost, path: "/reports/2021-06-30/reports", rateLimit: 0.0167, handler: s.handleCreateReport},
+		{operation: OperationGetReport, method: http.MethodGet, path: "/reports/2021-06-30/reports/{reportId}", rateLimit: 2, handler: s.handleGetReport},
+		{operation: OperationCancelReport, method: http.MethodDelete, path: "/reports/2021-06-30/reports/{reportId}", rateLimit: 0.0222, handler: s.handleCancelReport},
+		{operation: OperationGetReportDocument, method: http.MethodGet, path: "/reports/2021-06-30/documents/{reportDocumentId}", rateLimit: 0.0167, handler: s.handleGetReportDocument},
+		{operation: OperationGetFeeds, method: http.MethodGet, path: "/feeds/2021-06-30/feeds", rateLimit: 0.0222, handler: s.handleGetFeeds},
+		{operation: OperationCreateFeed, method: http.MethodPost, path: "/feeds/2021-06-30/feeds", rateLimit: 0.0083, handler: s.handleCreateFeed},
+		{operation: OperationGetFeed, method: http.MethodGet, path: "/feeds/2021-06-30/feeds/{feedId}", rateLimit: 2, handler: s.handleGetFeed},
+		{operation: OperationCancelFeed, method: http.MethodDelete, path: "/feeds/2021-06-30/feeds/{feedId}", rateLimit: 2, handler: s.handleCancelFeed},
+		{operation: OperationCreateFeedDocument, method: http.MethodPost, path: "/feeds/2021-06-30/documents", rateLimit: 0.5, handler: s.handleCreateFeedDocument},
+		{operation: OperationGetFeedDocument, method: http.MethodGet, path: "/feeds/2021-06-30/documents/{feedDocumentId}", rateLimit: 0.0222, handler: s.handleGetFeedDocument},
+		{operation: OperationSearchOrders, method: http.MethodGet, path: "/orders/2026-01-01/orders", rateLimit: 0.0167, handler: s.handleSearchOrders},
+		{operation: OperationGetOrder, method: http.MethodGet, path: "/orders/2026-01-01/orders/{orderId}", rateLimit: 0.0167, handler: s.handleGetOrder},
+		{operation: OperationGetOrdersV0, method: http.MethodGet, path: "/orders/v0/orders", rateLimit: 0.0167, handler: s.handleGetOrdersV0},
+		{operation: OperationGetOrderV0, method: http.MethodGet, path: "/orders/v0/orders/{orderId}", rateLimit: 0.5, handler: s.handleGetOrderV0},
+		{operation: OperationListFinancialEventGroups, method: http.MethodGet, path: "/finances/v0/financialEventGroups", rateLimit: 0.5, handler: s.handleListFinancialEventGroups},
+		{operation: OperationListFinancialEvents, method: http.MethodGet, path: "/finances/v0/financialEvents", rateLimit: 0.5, handler: s.handleListFinancialEvents},
+		{operation: OperationDownloadDocument, method: http.MethodGet, path: "/spapitest/documents/{documentId}", public: true, handler: s.handleDownloadDocument},
+		{operation: OperationUploadDocument, method: http.MethodPut, path: "/spapitest/documents/{documentId}", public: true, handler: s.handleUploadDocument},
+	}
+	for i := range s.routes {
+		s.routes[i].segments = strings.Split(s.routes[i].path, "/")
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	tb.Cleanup(s.server.Close)
+	return s
+}
+
+// NewClient creates a client for the server which is closed by the cleanup of the server's tb.
+// Unset credentials of the config are set to the ones accepted by the server, Log defaults to a
+// logger for errors.
+func (s *Server) NewClient(config sp_api.Config) *sp_api.Client {
+	s.tb.Helper()
+	if config.ClientID == "" {
+		config.ClientID = ClientID
+	}
+	if config.ClientSecret == "" {
+		config.ClientSecret = ClientSecret
+	}
+	if config.RefreshToken == "" {
+		config.RefreshToken = RefreshToken
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = s.server.Client()
+	}
+	if config.Log == nil {
+		config.Log = logger.New(logger.LvlError)
+	}
+	config.Endpoint = constants.Endpoint(s.URL)
+	config.Marketplace = ""
+	config.Sandbox = false
+	config.TokenURL = s.URL + TokenPath
+
+	client, err := sp_api.NewClient(config)
+	if err != nil {
+		s.tb.Fatalf("spapitest: failed to create client: %v", err)
+	}
+	s.tb.Cleanup(client.Close)
+	return client
+}
+
+// Throttle responds to the next calls of the operation with HTTP 429.
+func (s *Server) Throttle(operation string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.throttles[operation] += times
+}
+
+// Fail responds to the next call of the operation with the status and errors. Calling it
+// more than once queues the failures.
+func (s *Server) Fail(operation string, status int, errs ...apis.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[operation] = append(s.failures[operation], Failure{Status: status, Errors: errs})
+}
+
+// Requests returns the received requests of the operation, or all requests if operation is empty.
+func (s *Server) Requests(operation string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []Request
+	for _, r := range s.requests {
+		if operation == "" || r.Operation == operation {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// AssertCalled fails the test if the operation was not called exactly times.
+func (s *Server) AssertCalled(tb testing.TB, operation string, times int) bool {
+	tb.Helper()
+	if n := len(s.Requests(operation)); n != times {
+		tb.Errorf("spapitest: %s was called %d times, expected %d", operation, n, times)
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", err.Error())
+		return
+	}
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+	rt, params, ok := s.match(r)
+	request := Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Params: params,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+	if ok {
+		request.Operation = rt.operation
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	throttled := ok && s.throttles[rt.operation] > 0
+	var failure *Failure
+	if throttled {
+		s.throttles[rt.operation]--
+	} else if ok && len(s.failures[rt.operation]) > 0 {
+		failure = &s.failures[rt.operation][0]
+		s.failures[rt.operation] = s.failures[rt.operation][1:]
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "NotFound", "Resource not found: "+r.Method+" "+r.URL.Path)
+		return
+	}
+	if rt.rateLimit > 0 {
+		w.Header().Set(constants.RateLimitHeader, strconv.FormatFloat(rt.rateLimit, 'f', -1, 64))
+	}
+	if !rt.public {
+		token := r.Header.Get(constants.AccessTokenHeader)
+		if token != AccessToken && token != RestrictedDataToken {
+			writeErrors(w, http.StatusForbidden, "Unauthorized", "Access to requested resource is denied.")
+			return
+		}
+	}
+
+	switch {
+	case throttled:
+		writeErrors(w, http.StatusTooManyRequests, "QuotaExceeded", "You exceeded your quota for the requested resource.")
+	case failure != nil && rt.operation == OperationToken:
+		code, description := "server_error", ""
+		if len(failure.Errors) > 0 {
+			code, description = failure.Errors[0].Code, failure.Errors[0].Message
+		}
+		writeJSON(w, failure.Status, map[string]string{"error": code, "error_description": description})
+	case failure != nil:
+		writeJSON(w, failure.Status, apis.ErrorList{Errors: failure.Errors})
+	default:
+		rt.handler(w, r, params)
+	}
+}
+
+func (s *Server) match(r *http.Request) (route, map[string]string, bool) {
+	segments := strings.Split(r.URL.Path, "/")
+	for _, rt := range s.routes {
+		if rt.method != r.Method || len(rt.segments) != len(segments) {
+			continue
+		}
+		params := map[string]string{}
+		matched := true
+		for i, segment := range rt.segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				params[strings.Trim(segment, "{}")] = segments[i]
+			} else if segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rt, params, true
+		}
+	}
+	return route{}, nil, false
+}
+
+// newID returns a unique identifier with the prefix.
+func (s *Server) newID(prefix string) string {
+	s.lastID++
+	return fmt.Sprintf("%s-%d", prefix, s.lastID)
+}
+
+// pageQuery returns the query of the request or the query of the page token which it contains.
+// The caller must hold s.mu.
+func (s *Server) pageQuery(query url.Values, tokenParam string) (url.Values, int, bool) {
+	token := query.Get(tokenParam)
+	if token == "" {
+		return query, 0, true
+	}
+	page, ok := s.pageTokens[token]
+	return page.query, page.offset, ok
+}
+
+// page returns the items of the page at offset and the token of the next page if there is one.
+// The caller must hold s.mu.
+func page[T any](s *Server, items []T, query url.Values, offset, size int) ([]T, *string) {
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + size
+	if end >= len(items) {
+		return items[offset:], nil
+	}
+	token := s.newID("page")
+	s.pageTokens[token] = pageToken{query: query, offset: end}
+	return items[offset:end], &token
+}
+
+// pageSize parses the page size parameter, def is used if it is not set.
+func pageSize(query url.Values, param string, def int) (int, error) {
+	value := query.Get(param)
+	if value == "" {
+		return def, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 1 {
+		return 0, fmt.Errorf("invalid %s %q", param, value)
+	}
+	return size, nil
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var params map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request", "error_description": err.Error()})
+		return
+	}
+	if params["client_id"] != ClientID || params["client_secret"] != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client", "error_description": "Client authentication failed"})
+		return
+	}
+
+	switch params["grant_type"] {
+	case "refresh_token":
+		if params["refresh_token"] != RefreshToken {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "The request has an invalid grant parameter : refresh_token"})
+			return
+		}
+	case "client_credentials":
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type", "error_description": params["grant_type"]})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token":  AccessToken,
+		"refresh_token": params["refresh_token"],
+		"token_type":    "bearer",
+		"expires_in":    3600,
+	})
+}
+
+func (s *Server) handleCreateRestrictedDataToken(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"restrictedDataToken": RestrictedDataToken,
+		"expiresIn":           3600,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeErrors(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, apis.ErrorList{Errors: []apis.Error{{Code: code, Message: message}}})
+}
+
+// decodeBody decodes the JSON body of the request and responds with HTTP 400 if it is invalid.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// commaValues splits the comma separated values of a query parameter.
+func commaValues(query url.Values, param string) []string {
+	var values []string
+	for _, v := range strings.Split(query.Get(param), ",") {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package spapitest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	sp_api "github.com/fond-of-vertigo/amazon-sp-api"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/feeds"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/finances"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/orders"
+	"github.com/fond-of-vertigo/amazon-sp-api/apis/reports"
+	"github.com/fond-of-vertigo/amazon-sp-api/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func download(t *testing.T, url string) []byte {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return content
+}
+
+func TestServer_ReportLifecycle(t *testing.T) {
+	s := NewServer(t)
+	s.ScriptReport(reports.FBAAmazonFulfilledInventoryReport, ReportScript{
+		Statuses: []constants.ProcessingStatus{constants.InQueue, constants.InProgress, constants.InProgress, constants.Done},
+		Document: []byte("sku\tquantity\nA\t1\n"),
+		Compress: true,
+	})
+	client := s.NewClient(sp_api.Config{})
+
+	created, err := client.ReportsAPI.CreateReport(&reports.CreateReportSpecification{
+		ReportType:     reports.FBAAmazonFulfilledInventoryReport,
+		MarketplaceIDs: []constants.MarketplaceID{constants.Germany},
+	})
+	require.NoError(t, err)
+
+	var statuses []constants.ProcessingStatus
+	var report *reports.GetReportResponse
+	for report == nil || !report.ProcessingStatus.IsDone() {
+		resp, err := client.ReportsAPI.GetReport(created.ResponseBody.ReportID)
+		require.NoError(t, err)
+		report = resp.ResponseBody
+		statuses = append(statuses, report.ProcessingStatus)
+	}
+	assert.Equal(t, []constants.ProcessingStatus{constants.InProgress, constants.InProgress, constants.Done}, statuses)
+	require.NotNil(t, report.ReportDocumentID)
+
+	empty := ""
+	doc, err := client.ReportsAPI.GetReportDocument(*report.ReportDocumentID, &empty)
+	require.NoError(t, err)
+	require.NotNil(t, doc.ResponseBody.CompressionAlgorithm)
+	assert.Equal(t, "GZIP", *doc.ResponseBody.CompressionAlgorithm)
+
+	zr, err := gzip.NewReader(bytes.NewReader(download(t, doc.ResponseBody.Url)))
+	require.NoError(t, err)
+	content, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, "sku\tquantity\nA\t1\n", string(content))
+
+	s.AssertCalled(t, OperationGetReport, 3)
+	requests := s.Requests(OperationCreateReport)
+	require.Len(t, requests, 1)
+	assert.Equal(t, AccessToken, requests[0].Header.Get(constants.AccessTokenHeader))
+	assert.Contains(t, string(requests[0].Body), string(constants.Germany))
+}
+
+func TestServer_FeedDocuments(t *testing.T) {
+	s := NewServer(t)
+	s.ScriptFeed("POST_PRODUCT_DATA", FeedScript{ResultDocument: []byte("processing report")})
+	client := s.NewClient(sp_api.Config{})
+
+	created, err := client.FeedsAPI.CreateFeedDocument(&feeds.CreateFeedDocumentSpecification{ContentType: "text/xml; charset=UTF-8"})
+	require.NoError(t, err)
+	documentID := created.ResponseBody.FeedDocumentId
+
+	_, err = client.FeedsAPI.CreateFeed(&feeds.CreateFeedSpecification{
+		FeedType:            "POST_PRODUCT_DATA",
+		MarketplaceIDs:      []constants.MarketplaceID{constants.Germany},
+		InputFeedDocumentId: documentID,
+	})
+	assert.Error(t, err, "feed document was not uploaded")
+
+	req, err := http.NewRequest(http.MethodPut, created.ResponseBody.Url, bytes.NewBufferString("<feed/>"))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	uploaded, ok := s.UploadedFeedDocument(documentID)
+	require.True(t, ok)
+	assert.Equal(t, "<feed/>", string(uploaded))
+
+	feed, err := client.FeedsAPI.CreateFeed(&feeds.CreateFeedSpecification{
+		FeedType:            "POST_PRODUCT_DATA",
+		MarketplaceIDs:      []constants.MarketplaceID{constants.Germany},
+		InputFeedDocumentId: documentID,
+	})
+	require.NoError(t, err)
+
+	var status *feeds.Feed
+	for status == nil || status.ProcessingStatus != feeds.ProcessingStatusDone {
+		resp, err := client.FeedsAPI.GetFeed(feed.ResponseBody.FeedId)
+		require.NoError(t, err)
+		status = resp.ResponseBody
+	}
+	require.NotNil(t, status.ResultFeedDocumentId)
+
+	result, err := client.FeedsAPI.GetFeedDocument(*status.ResultFeedDocumentId)
+	require.NoError(t, err)
+	assert.Equal(t, "processing report", string(download(t, result.ResponseBody.Url)))
+}
+
+func TestServer_SearchOrdersPagination(t *testing.T) {
+	s := NewServer(t)
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"1", "2", "3"} {
+		s.AddOrders(orders.Order{OrderID: id, CreatedTime: created, LastUpdatedTime: created, SalesChannel: orders.SalesChannel{MarketplaceID: string(constants.Germany)}})
+	}
+	s.AddOrders(orders.Order{OrderID: "too old", CreatedTime: created.Add(-time.Hour)})
+	client := s.NewClient(sp_api.Config{})
+
+	maxResults := 2
+	filter := &orders.SearchOrdersFilter{
+		CreatedAfter:      &apis.JsonTimeISO8601{Time: created},
+		MarketplaceIDs:    []constants.MarketplaceID{constants.Germany},
+		MaxResultsPerPage: &maxResults,
+	}
+	var orderIDs []string
+	for {
+		resp, err := client.OrdersAPI.SearchOrders(filter, nil)
+		require.NoError(t, err)
+		for _, order := range resp.ResponseBody.Orders {
+			orderIDs = append(orderIDs, order.OrderID)
+		}
+		nextToken := resp.ResponseBody.GetNextToken()
+		if nextToken == nil {
+			break
+		}
+		filter = &orders.SearchOrdersFilter{PaginationToken: nextToken}
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, orderIDs)
+	s.AssertCalled(t, OperationSearchOrders, 2)
+}
+
+func TestServer_ListFinancialEventsPagination(t *testing.T) {
+	s := NewServer(t)
+	first, second := "first", "second"
+	s.SetFinancialEventPages(
+		finances.FinancialEvents{ShipmentEventList: []finances.ShipmentEvent{{AmazonOrderId: &first}}},
+		finances.FinancialEvents{ShipmentEventList: []finances.ShipmentEvent{{AmazonOrderId: &second}}},
+	)
+	client := s.NewClient(sp_api.Config{})
+
+	var orderIDs []string
+	filter := &finances.ListFinancialEventsFilter{}
+	for {
+		resp, err := client.FinancesAPI.ListFinancialEvents(filter)
+		require.NoError(t, err)
+		payload := resp.ResponseBody.Payload
+		for _, event := range payload.FinancialEvents.ShipmentEventList {
+			orderIDs = append(orderIDs, *event.AmazonOrderId)
+		}
+		if payload.NextToken == nil {
+			break
+		}
+		filter = &finances.ListFinancialEventsFilter{NextToken: payload.NextToken}
+	}
+	assert.Equal(t, []string{"first", "second"}, orderIDs)
+}
+
+func TestServer_ThrottleAndFail(t *testing.T) {
+	s := NewServer(t)
+	client := s.NewClient(sp_api.Config{})
+	created, err := client.ReportsAPI.CreateReport(&reports.CreateReportSpecification{
+		ReportType:     reports.FBAAmazonFulfilledInventoryReport,
+		MarketplaceIDs: []constants.MarketplaceID{constants.Germany},
+	})
+	require.NoError(t, err)
+
+	s.Throttle(OperationGetReport, 1)
+	resp, err := client.ReportsAPI.GetReport(created.ResponseBody.ReportID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Status)
+	s.AssertCalled(t, OperationGetReport, 2)
+
+	s.Fail(OperationGetReport, http.StatusInternalServerError, apis.Error{Code: "InternalFailure", Message: "failure"})
+	resp, err = client.ReportsAPI.GetReport(created.ResponseBody.ReportID)
+	assert.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusInternalServerError, resp.Status)
+	assert.Equal(t, "InternalFailure", resp.ErrorList.Errors[0].Code)
+
+	_, err = client.ReportsAPI.GetReport("unknown")
+	assert.Error(t, err)
+}
+
+func TestServer_RejectsUnknownAccessToken(t *testing.T) {
+	s := NewServer(t)
+
+	req, err := http.NewRequest(http.MethodGet, s.URL+"/reports/2021-06-30/reports/report-1", nil)
+	require.NoError(t, err)
+	req.Header.Set(constants.AccessTokenHeader, "invalid")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}